@@ -0,0 +1,86 @@
+package aoc
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Kind describes what sort of value an Answer holds
+type Kind int
+
+const (
+	KindNone Kind = iota // No answer, e.g. alongside an error
+	KindInt              // A whole number
+	KindText             // Free-form text such as "6,5,4,7" or a password
+)
+
+// Integer is the set of integer types an Answer can be built from
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Answer is the solution to one part of a puzzle. Puzzles answer with either
+// a number or text, and tooling treats both the same way through String and
+// Equal.
+type Answer struct {
+	kind Kind
+	num  int64
+	text string
+}
+
+// Int returns a numeric answer
+func Int[T Integer](n T) Answer {
+	return Answer{kind: KindInt, num: int64(n)}
+}
+
+// Text returns a textual answer
+func Text(s string) Answer {
+	return Answer{kind: KindText, text: s}
+}
+
+// Kind reports what sort of value the answer holds
+func (a Answer) Kind() Kind {
+	return a.kind
+}
+
+// IsZero reports whether the answer holds no value
+func (a Answer) IsZero() bool {
+	return a.kind == KindNone
+}
+
+// Int returns the numeric value of the answer and whether it is numeric
+func (a Answer) Int() (int64, bool) {
+	return a.num, a.kind == KindInt
+}
+
+// String returns the answer as it would be typed into the puzzle page
+func (a Answer) String() string {
+	switch a.kind {
+	case KindInt:
+		return strconv.FormatInt(a.num, 10)
+	case KindText:
+		return a.text
+	default:
+		return ""
+	}
+}
+
+// Equal reports whether two answers would be accepted as the same, which is
+// the case when they are written the same way regardless of their kind.
+func (a Answer) Equal(other Answer) bool {
+	return a.kind != KindNone && other.kind != KindNone && a.String() == other.String()
+}
+
+// MarshalJSON encodes numeric answers as JSON numbers, textual answers as
+// strings and missing answers as null.
+func (a Answer) MarshalJSON() ([]byte, error) {
+	switch a.kind {
+	case KindInt:
+		return []byte(strconv.FormatInt(a.num, 10)), nil
+	case KindText:
+		return json.Marshal(a.text)
+	default:
+		return []byte("null"), nil
+	}
+}
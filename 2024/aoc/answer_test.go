@@ -0,0 +1,61 @@
+package aoc
+
+import "testing"
+
+func TestAnswerString(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer Answer
+		want   string
+		kind   Kind
+	}{
+		{"int", Int(93436), "93436", KindInt},
+		{"int64", Int(int64(38869984335432)), "38869984335432", KindInt},
+		{"negative", Int(-7), "-7", KindInt},
+		{"text", Text("6,5,4,7,1,6,0,3,1"), "6,5,4,7,1,6,0,3,1", KindText},
+		{"none", Answer{}, "", KindNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.answer.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := tt.answer.Kind(); got != tt.kind {
+				t.Errorf("Kind() = %v, want %v", got, tt.kind)
+			}
+		})
+	}
+}
+
+func TestAnswerEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Answer
+		want bool
+	}{
+		{"same int", Int(42), Int(int64(42)), true},
+		{"int and matching text", Int(42), Text("42"), true},
+		{"different ints", Int(42), Int(43), false},
+		{"different text", Text("a,b"), Text("a,c"), false},
+		{"none never matches", Answer{}, Answer{}, false},
+		{"none and empty text", Answer{}, Text(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("%v.Equal(%v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswerInt(t *testing.T) {
+	if n, ok := Int(12).Int(); !ok || n != 12 {
+		t.Errorf("Int(12).Int() = %d, %v, want 12, true", n, ok)
+	}
+	if _, ok := Text("12").Int(); ok {
+		t.Error("Text(\"12\").Int() reported a numeric answer")
+	}
+}
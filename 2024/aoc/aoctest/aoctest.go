@@ -0,0 +1,77 @@
+// Package aoctest provides benchmarks shared by every registered solver, so
+// each day only has to name itself to get the same parse and part timings.
+package aoctest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"aoc2024/aoc"
+)
+
+// input returns the default input of a puzzle
+func input(b *testing.B, year, day int) []byte {
+	b.Helper()
+
+	r, err := aoc.OpenInput(year, day, "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+// solver returns a fresh solver for a puzzle
+func solver(b *testing.B, year, day int) aoc.Solver {
+	b.Helper()
+
+	s, ok := aoc.Lookup(year, day)
+	if !ok {
+		b.Fatalf("puzzle %d day %d is not registered", year, day)
+	}
+	return s
+}
+
+// BenchmarkParse measures parsing the default input of a puzzle
+func BenchmarkParse(b *testing.B, year, day int) {
+	data := input(b, year, day)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := solver(b, year, day).Parse(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPart measures solving one part of a puzzle, parsing the default
+// input once beforehand. Puzzles without the part are skipped.
+func BenchmarkPart(b *testing.B, year, day, part int) {
+	s := solver(b, year, day)
+	if err := s.Parse(bytes.NewReader(input(b, year, day))); err != nil {
+		b.Fatal(err)
+	}
+
+	solve := s.Part1
+	if part == 2 {
+		solve = s.Part2
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := solve()
+		if errors.Is(err, aoc.ErrNoPart) {
+			b.Skip(err)
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package aoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Timing holds how long each phase of a puzzle took. A zero part duration
+// means the puzzle has no such part.
+type Timing struct {
+	Year  int           `json:"year"`
+	Day   int           `json:"day"`
+	Parse time.Duration `json:"parse_ns"`
+	Part1 time.Duration `json:"part1_ns"`
+	Part2 time.Duration `json:"part2_ns,omitempty"`
+}
+
+// Total returns the time taken by all phases together
+func (t Timing) Total() time.Duration {
+	return t.Parse + t.Part1 + t.Part2
+}
+
+// phase is one named step of solving a puzzle
+type phase struct {
+	name     string
+	duration time.Duration
+}
+
+// phases returns the named durations of a timing in the order they run
+func (t Timing) phases() []phase {
+	return []phase{
+		{"parse", t.Parse},
+		{"part1", t.Part1},
+		{"part2", t.Part2},
+	}
+}
+
+// Measure solves a puzzle the given number of times, each with a fresh
+// solver, and keeps the fastest run of every phase as that is the least
+// affected by noise.
+func Measure(year, day int, input []byte, runs int) (Timing, error) {
+	if runs < 1 {
+		return Timing{}, fmt.Errorf("invalid number of runs %d", runs)
+	}
+
+	var best Timing
+	for run := 0; run < runs; run++ {
+		solver, ok := Lookup(year, day)
+		if !ok {
+			return Timing{}, fmt.Errorf("puzzle %d day %d is not registered", year, day)
+		}
+
+		start := time.Now()
+		if err := solver.Parse(bytes.NewReader(input)); err != nil {
+			return Timing{}, fmt.Errorf("parsing input: %w", err)
+		}
+		timing := Timing{Year: year, Day: day, Parse: time.Since(start)}
+
+		for p, solve := range []func() (Answer, error){solver.Part1, solver.Part2} {
+			start := time.Now()
+			_, err := solve()
+			elapsed := time.Since(start)
+			if errors.Is(err, ErrNoPart) {
+				continue
+			}
+			if err != nil {
+				return Timing{}, fmt.Errorf("part %d: %w", p+1, err)
+			}
+
+			if p == 0 {
+				timing.Part1 = elapsed
+			} else {
+				timing.Part2 = elapsed
+			}
+		}
+
+		if run == 0 {
+			best = timing
+			continue
+		}
+		best.Parse = min(best.Parse, timing.Parse)
+		best.Part1 = min(best.Part1, timing.Part1)
+		best.Part2 = min(best.Part2, timing.Part2)
+	}
+	return best, nil
+}
+
+// noiseFloor is the smallest slowdown reported as a regression, as phases
+// that run in well under a millisecond vary by more than any sensible
+// threshold from one run to the next.
+const noiseFloor = time.Millisecond
+
+// Regressions compares a timing against its baseline and returns the names
+// of the phases that became slower by more than the given fraction.
+func Regressions(base, current Timing, threshold float64) []string {
+	var slower []string
+	currentPhases := current.phases()
+	for i, phase := range base.phases() {
+		now := currentPhases[i].duration
+		if phase.duration == 0 || now-phase.duration < noiseFloor {
+			continue
+		}
+		if float64(now) > float64(phase.duration)*(1+threshold) {
+			slower = append(slower, phase.name)
+		}
+	}
+	return slower
+}
+
+// Baseline is a set of recorded timings to compare later runs against
+type Baseline []Timing
+
+// ReadBaseline decodes a baseline saved by Baseline.Write
+func ReadBaseline(r io.Reader) (Baseline, error) {
+	var baseline Baseline
+	if err := json.NewDecoder(r).Decode(&baseline); err != nil {
+		return nil, fmt.Errorf("decoding baseline: %w", err)
+	}
+	return baseline, nil
+}
+
+// Find returns the recorded timing of a puzzle
+func (b Baseline) Find(year, day int) (Timing, bool) {
+	for _, timing := range b {
+		if timing.Year == year && timing.Day == day {
+			return timing, true
+		}
+	}
+	return Timing{}, false
+}
+
+// Update records a timing, replacing any earlier timing of the same puzzle,
+// and keeps the baseline ordered by puzzle.
+func (b Baseline) Update(timing Timing) Baseline {
+	for i := range b {
+		if b[i].Year == timing.Year && b[i].Day == timing.Day {
+			b[i] = timing
+			return b
+		}
+	}
+
+	b = append(b, timing)
+	sort.Slice(b, func(i, j int) bool {
+		if b[i].Year != b[j].Year {
+			return b[i].Year < b[j].Year
+		}
+		return b[i].Day < b[j].Day
+	})
+	return b
+}
+
+// Write encodes the baseline as indented JSON
+func (b Baseline) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
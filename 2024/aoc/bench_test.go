@@ -0,0 +1,72 @@
+package aoc
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRegressions(t *testing.T) {
+	base := Timing{Parse: time.Millisecond, Part1: 10 * time.Millisecond, Part2: 100 * time.Millisecond}
+
+	tests := []struct {
+		name    string
+		current Timing
+		want    []string
+	}{
+		{
+			name:    "unchanged",
+			current: base,
+		},
+		{
+			name:    "within threshold",
+			current: Timing{Parse: time.Millisecond, Part1: 11 * time.Millisecond, Part2: 110 * time.Millisecond},
+		},
+		{
+			name:    "slower part",
+			current: Timing{Parse: time.Millisecond, Part1: 10 * time.Millisecond, Part2: 200 * time.Millisecond},
+			want:    []string{"part2"},
+		},
+		{
+			name:    "slowdown below the noise floor",
+			current: Timing{Parse: 1900 * time.Microsecond, Part1: 10 * time.Millisecond, Part2: 100 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Regressions(base, tt.current, 0.2)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Regressions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	var baseline Baseline
+	baseline = baseline.Update(Timing{Year: 2024, Day: 16, Parse: 3, Part1: 4, Part2: 5})
+	baseline = baseline.Update(Timing{Year: 2024, Day: 1, Parse: 1, Part1: 2})
+	baseline = baseline.Update(Timing{Year: 2024, Day: 16, Parse: 6, Part1: 7, Part2: 8})
+
+	var buf bytes.Buffer
+	if err := baseline.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadBaseline(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Baseline{
+		{Year: 2024, Day: 1, Parse: 1, Part1: 2},
+		{Year: 2024, Day: 16, Parse: 6, Part1: 7, Part2: 8},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("baseline after round trip = %v, want %v", got, want)
+	}
+	if timing, ok := got.Find(2024, 16); !ok || timing.Part2 != 8 {
+		t.Errorf("Find(2024, 16) = %v, %v", timing, ok)
+	}
+}
@@ -0,0 +1,43 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var inputs = make(map[puzzleKey]string)
+
+// Embed records the puzzle input compiled into a day's package, which is
+// used whenever no other input is given. Like Register, it is intended to be
+// called from the day's init function.
+func Embed(year, day int, input string) {
+	inputs[puzzleKey{year, day}] = input
+}
+
+// OpenInput opens the input of a puzzle. A path of "-" reads standard input
+// and any other non-empty path is opened as a file. Without a path the
+// embedded input is used, falling back to the day's input.txt on disk.
+func OpenInput(year, day int, path string) (io.ReadCloser, error) {
+	switch path {
+	case "-":
+		return io.NopCloser(os.Stdin), nil
+	case "":
+		if input, ok := inputs[puzzleKey{year, day}]; ok {
+			return io.NopCloser(strings.NewReader(input)), nil
+		}
+
+		found, err := InputPath(year, day)
+		if err != nil {
+			return nil, err
+		}
+		path = found
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening input: %w", err)
+	}
+	return file, nil
+}
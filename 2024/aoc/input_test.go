@@ -0,0 +1,50 @@
+package aoc
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenInput(t *testing.T) {
+	Embed(1, 1, "embedded")
+	t.Cleanup(func() { delete(inputs, puzzleKey{1, 1}) })
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("from file"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "embedded by default", path: "", want: "embedded"},
+		{name: "explicit file", path: path, want: "from file"},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.txt"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := OpenInput(1, 1, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OpenInput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			defer input.Close()
+
+			got, err := io.ReadAll(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("OpenInput() read %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format selects how results are written
+type Format string
+
+const (
+	FormatText Format = "text" // One line per part, meant to be read by people
+	FormatJSON Format = "json" // One JSON record per part and line, and nothing else
+)
+
+// ParseFormat checks the name of an output format
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case FormatText, FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q", name)
+	}
+}
+
+// Output writes the results of puzzles in a single format. It is shared by
+// every command that prints answers so they all look alike.
+type Output struct {
+	w       io.Writer
+	format  Format
+	headers int
+}
+
+// NewOutput returns an Output writing to w
+func NewOutput(w io.Writer, format Format) *Output {
+	return &Output{w: w, format: format}
+}
+
+// Header introduces the results of a puzzle when several are written
+// together. JSON records carry the puzzle themselves, so JSON output has no
+// headers.
+func (o *Output) Header(year, day int) {
+	if o.format != FormatText {
+		return
+	}
+	if o.headers > 0 {
+		fmt.Fprintln(o.w)
+	}
+	o.headers++
+	fmt.Fprintf(o.w, "Day %d\n", day)
+}
+
+// Write writes the results of one puzzle
+func (o *Output) Write(results []Result) error {
+	if o.format == FormatJSON {
+		return writeJSON(o.w, results)
+	}
+	PrintResults(o.w, results)
+	return nil
+}
+
+// PrintResults writes one line per result in a uniform format
+func PrintResults(w io.Writer, results []Result) {
+	for _, result := range results {
+		switch {
+		case result.Part == 0:
+			fmt.Fprintf(w, "Error: %v\n", result.Err)
+		case errors.Is(result.Err, ErrNoPart):
+			fmt.Fprintf(w, "Part %d: -\n", result.Part)
+		case result.Err != nil:
+			fmt.Fprintf(w, "Part %d: error: %v\n", result.Part, result.Err)
+		default:
+			fmt.Fprintf(w, "Part %d: %v\n", result.Part, result.Answer)
+		}
+	}
+}
+
+// record is the JSON form of a Result
+type record struct {
+	Year       int     `json:"year"`
+	Day        int     `json:"day"`
+	Part       int     `json:"part"`
+	Answer     Answer  `json:"answer"`
+	DurationNS int64   `json:"duration_ns"`
+	Error      *string `json:"error"`
+}
+
+// writeJSON writes one record per result. Parts that the puzzle does not
+// have are left out rather than reported as errors.
+func writeJSON(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if errors.Is(result.Err, ErrNoPart) {
+			continue
+		}
+
+		rec := record{
+			Year:       result.Year,
+			Day:        result.Day,
+			Part:       result.Part,
+			Answer:     result.Answer,
+			DurationNS: result.Duration.Nanoseconds(),
+		}
+		if result.Err != nil {
+			message := result.Err.Error()
+			rec.Error = &message
+		}
+		if err := encoder.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package aoc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintResults(t *testing.T) {
+	var out strings.Builder
+	PrintResults(&out, []Result{
+		{Part: 1, Answer: Int(42)},
+		{Part: 2, Err: ErrNoPart},
+	})
+
+	want := "Part 1: 42\nPart 2: -\n"
+	if out.String() != want {
+		t.Errorf("PrintResults() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	results := []Result{
+		{Year: 2024, Day: 17, Part: 1, Answer: Text("4,6,3"), Duration: 1500},
+		{Year: 2024, Day: 17, Part: 2, Answer: Int(117440), Duration: 2 * time.Millisecond},
+	}
+
+	tests := []struct {
+		name    string
+		format  Format
+		results [][]Result
+		want    string
+	}{
+		{
+			name:    "text",
+			format:  FormatText,
+			results: [][]Result{results},
+			want:    "Day 17\nPart 1: 4,6,3\nPart 2: 117440\n",
+		},
+		{
+			name:    "json",
+			format:  FormatJSON,
+			results: [][]Result{results},
+			want: `{"year":2024,"day":17,"part":1,"answer":"4,6,3","duration_ns":1500,"error":null}` + "\n" +
+				`{"year":2024,"day":17,"part":2,"answer":117440,"duration_ns":2000000,"error":null}` + "\n",
+		},
+		{
+			name:   "json errors and missing parts",
+			format: FormatJSON,
+			results: [][]Result{
+				{{Year: 2024, Day: 3, Err: errors.New("parsing input: bad")}},
+				{{Year: 2024, Day: 25, Part: 1, Answer: Int(3127)}, {Year: 2024, Day: 25, Part: 2, Err: ErrNoPart}},
+			},
+			want: `{"year":2024,"day":3,"part":0,"answer":null,"duration_ns":0,"error":"parsing input: bad"}` + "\n" +
+				`{"year":2024,"day":25,"part":1,"answer":3127,"duration_ns":0,"error":null}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			output := NewOutput(&out, tt.format)
+			for _, results := range tt.results {
+				output.Header(results[0].Year, results[0].Day)
+				if err := output.Write(results); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"text", "json"} {
+		if _, err := ParseFormat(name); err != nil {
+			t.Errorf("ParseFormat(%q) error = %v", name, err)
+		}
+	}
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Error("ParseFormat(\"yaml\") did not return an error")
+	}
+}
@@ -0,0 +1,166 @@
+package aoc
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Result holds the outcome of solving one part of a puzzle. A result for
+// part 0 stands for a failure that kept every part from being solved, such
+// as a missing or malformed input.
+type Result struct {
+	Year, Day, Part int
+	Answer          Answer
+	Duration        time.Duration
+	Err             error
+}
+
+// Solve parses the input and runs the requested part, or both parts when
+// part is 0. A parse failure is returned as an error, while failures of the
+// individual parts are reported in their results.
+func Solve(year, day int, s Solver, r io.Reader, part int) ([]Result, error) {
+	if part < 0 || part > 2 {
+		return nil, fmt.Errorf("invalid part %d", part)
+	}
+
+	if err := s.Parse(r); err != nil {
+		return nil, fmt.Errorf("parsing input: %w", err)
+	}
+
+	var results []Result
+	for p, solve := range []func() (Answer, error){s.Part1, s.Part2} {
+		if part != 0 && part != p+1 {
+			continue
+		}
+		start := time.Now()
+		answer, err := solve()
+		results = append(results, Result{Year: year, Day: day, Part: p + 1, Answer: answer, Duration: time.Since(start), Err: err})
+	}
+	return results, nil
+}
+
+// InputPath locates the input file of a puzzle, looking in the day's
+// directory relative to either the year directory or the repository root.
+func InputPath(year, day int) (string, error) {
+	return dayFile(year, day, "input.txt")
+}
+
+// AnswersPath locates the file of known-good answers of a puzzle, which
+// lives next to its input.
+func AnswersPath(year, day int) (string, error) {
+	return dayFile(year, day, "answers.json")
+}
+
+// dayFile finds a file in the directory of a puzzle
+func dayFile(year, day int, name string) (string, error) {
+	dayDir := fmt.Sprintf("day-%02d", day)
+	candidates := []string{
+		filepath.Join(dayDir, name),
+		filepath.Join(strconv.Itoa(year), dayDir, name),
+	}
+
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no %s found for %d day %d: %w", name, year, day, fs.ErrNotExist)
+}
+
+// Failed reports whether any of the results carries an error other than
+// ErrNoPart.
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, ErrNoPart) {
+			return true
+		}
+	}
+	return false
+}
+
+// Main is the entry point of the single-day binaries. It solves the given
+// puzzle using the input named by the -input flag, or the embedded input
+// when the flag is not set, and writes the results in the format chosen
+// with -format. A day can define flags of its own before calling Main and
+// act on them in setup functions, which run once the flags are parsed.
+func Main(year, day int, setup ...func()) {
+	MainWith(year, day, func() (Solver, error) {
+		for _, f := range setup {
+			f()
+		}
+		return lookup(year, day)
+	})
+}
+
+// MainWith is Main for days whose solver is configured by flags of their
+// own. Once the flags are parsed, newSolver builds the solver to run; an
+// error from it is reported as a usage error.
+func MainWith(year, day int, newSolver func() (Solver, error)) {
+	part := flag.Int("part", 0, "part to solve (1 or 2, 0 for both)")
+	inputPath := flag.String("input", "", "puzzle input file, or - for stdin (default: embedded input)")
+	formatName := flag.String("format", "text", "output format (text or json)")
+	flag.Parse()
+
+	solver, err := newSolver()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	format, err := ParseFormat(*formatName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	results, err := RunSolver(year, day, solver, *part, *inputPath)
+	if err != nil {
+		results = []Result{{Year: year, Day: day, Err: err}}
+	}
+
+	if err := NewOutput(os.Stdout, format).Write(results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing results: %v\n", err)
+		os.Exit(1)
+	}
+	if Failed(results) {
+		os.Exit(1)
+	}
+}
+
+// Run solves a registered puzzle like Solve, reading the input from the
+// given path as described by OpenInput.
+func Run(year, day, part int, path string) ([]Result, error) {
+	solver, err := lookup(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return RunSolver(year, day, solver, part, path)
+}
+
+// RunSolver is Run with a solver of the caller's making rather than the
+// registered one
+func RunSolver(year, day int, solver Solver, part int, path string) ([]Result, error) {
+	input, err := OpenInput(year, day, path)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	return Solve(year, day, solver, input, part)
+}
+
+// lookup is Lookup failing with an error for unregistered puzzles
+func lookup(year, day int) (Solver, error) {
+	solver, ok := Lookup(year, day)
+	if !ok {
+		return nil, fmt.Errorf("puzzle %d day %d is not registered", year, day)
+	}
+	return solver, nil
+}
@@ -0,0 +1,78 @@
+// Package aoc defines the contract between the daily puzzle solutions and
+// the aoc runner, and keeps the registry of every solver linked into a
+// binary.
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Solver solves both parts of a single puzzle. Parse is called once with the
+// puzzle input before either part is requested, and the parts must not
+// modify the parsed state so they can be run in any order.
+type Solver interface {
+	Parse(r io.Reader) error
+	Part1() (Answer, error)
+	Part2() (Answer, error)
+}
+
+// ErrNoPart is returned by puzzles that do not have the requested part, such
+// as the second half of the final day.
+var ErrNoPart = errors.New("puzzle has no such part")
+
+// puzzleKey identifies a puzzle in the registry
+type puzzleKey struct {
+	year, day int
+}
+
+var registry = make(map[puzzleKey]func() Solver)
+
+// Register makes a solver available to the runner. It is intended to be
+// called from the init function of each day's package and panics if the
+// same puzzle is registered twice.
+func Register(year, day int, newSolver func() Solver) {
+	key := puzzleKey{year, day}
+	if _, exists := registry[key]; exists {
+		panic(fmt.Sprintf("aoc: puzzle %d day %d registered twice", year, day))
+	}
+	registry[key] = newSolver
+}
+
+// Lookup returns a fresh solver for the given puzzle.
+func Lookup(year, day int) (Solver, bool) {
+	newSolver, exists := registry[puzzleKey{year, day}]
+	if !exists {
+		return nil, false
+	}
+	return newSolver(), true
+}
+
+// Years returns the years with at least one registered day in ascending
+// order.
+func Years() []int {
+	seen := make(map[int]bool)
+	var years []int
+	for key := range registry {
+		if !seen[key.year] {
+			seen[key.year] = true
+			years = append(years, key.year)
+		}
+	}
+	sort.Ints(years)
+	return years
+}
+
+// Days returns the registered days of a year in ascending order.
+func Days(year int) []int {
+	var days []int
+	for key := range registry {
+		if key.year == year {
+			days = append(days, key.day)
+		}
+	}
+	sort.Ints(days)
+	return days
+}
@@ -0,0 +1,115 @@
+package aoc
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lineSolver is a minimal solver that counts lines and characters
+type lineSolver struct {
+	lines []string
+}
+
+func (s *lineSolver) Parse(r io.Reader) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(content) == 0 {
+		return errors.New("empty input")
+	}
+	s.lines = strings.Split(strings.TrimSpace(string(content)), "\n")
+	return nil
+}
+
+func (s *lineSolver) Part1() (Answer, error) {
+	return Int(len(s.lines)), nil
+}
+
+func (s *lineSolver) Part2() (Answer, error) {
+	return Answer{}, ErrNoPart
+}
+
+func TestRegistry(t *testing.T) {
+	Register(1999, 3, func() Solver { return &lineSolver{} })
+	Register(1999, 1, func() Solver { return &lineSolver{} })
+
+	if got := Days(1999); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Days(1999) = %v, want [1 3]", got)
+	}
+
+	if _, ok := Lookup(1999, 2); ok {
+		t.Error("Lookup(1999, 2) found an unregistered puzzle")
+	}
+
+	first, ok := Lookup(1999, 1)
+	if !ok {
+		t.Fatal("Lookup(1999, 1) did not find the registered puzzle")
+	}
+	second, _ := Lookup(1999, 1)
+	if first == second {
+		t.Error("Lookup returned the same solver twice, want a fresh one per call")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a puzzle twice did not panic")
+		}
+	}()
+	Register(1999, 1, func() Solver { return &lineSolver{} })
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		part    int
+		want    []Result
+		wantErr bool
+	}{
+		{
+			name: "both parts",
+			part: 0,
+			want: []Result{
+				{Year: 1999, Day: 1, Part: 1, Answer: Int(3)},
+				{Year: 1999, Day: 1, Part: 2, Err: ErrNoPart},
+			},
+		},
+		{
+			name: "part one only",
+			part: 1,
+			want: []Result{{Year: 1999, Day: 1, Part: 1, Answer: Int(3)}},
+		},
+		{
+			name:    "invalid part",
+			part:    3,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := Solve(1999, 1, &lineSolver{}, strings.NewReader("a\nb\nc\n"), tt.part)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Solve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i := range results {
+				if results[i].Duration < 0 {
+					t.Errorf("Solve() part %d took %v", results[i].Part, results[i].Duration)
+				}
+				results[i].Duration = 0
+			}
+			if !reflect.DeepEqual(results, tt.want) {
+				t.Errorf("Solve() = %v, want %v", results, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolveParseError(t *testing.T) {
+	if _, err := Solve(1999, 1, &lineSolver{}, strings.NewReader(""), 0); err == nil {
+		t.Error("Solve() with empty input did not return an error")
+	}
+}
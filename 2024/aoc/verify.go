@@ -0,0 +1,133 @@
+package aoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Expected holds the known-good answers of a puzzle, keyed by part. Parts
+// without a recorded answer are absent.
+type Expected map[int]string
+
+// ReadExpected decodes an answers file of the form
+//
+//	{"part1": 1388114, "part2": "cl,df,ft"}
+//
+// Answers may be written as JSON numbers or strings.
+func ReadExpected(r io.Reader) (Expected, error) {
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding answers: %w", err)
+	}
+
+	expected := make(Expected)
+	for key, value := range raw {
+		var part int
+		if _, err := fmt.Sscanf(key, "part%d", &part); err != nil || (part != 1 && part != 2) {
+			return nil, fmt.Errorf("unknown key %q in answers", key)
+		}
+
+		answer, err := decodeAnswer(value)
+		if err != nil {
+			return nil, fmt.Errorf("answer to part %d: %w", part, err)
+		}
+		expected[part] = answer
+	}
+	return expected, nil
+}
+
+// decodeAnswer accepts either a JSON string or a JSON number
+func decodeAnswer(value json.RawMessage) (string, error) {
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		return text, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var number json.Number
+	if err := decoder.Decode(&number); err != nil {
+		return "", fmt.Errorf("expected a string or a number, got %s", value)
+	}
+	return number.String(), nil
+}
+
+// LoadExpected reads the answers file of a puzzle. A puzzle without an
+// answers file has no expected answers, which is not an error.
+func LoadExpected(year, day int) (Expected, error) {
+	path, err := AnswersPath(year, day)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Expected{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	expected, err := ReadExpected(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return expected, nil
+}
+
+// Status is the outcome of checking one result against its expected answer
+type Status int
+
+const (
+	Missing Status = iota // no answer has been recorded for the part
+	Pass
+	Fail
+)
+
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "pass"
+	case Fail:
+		return "FAIL"
+	}
+	return "missing"
+}
+
+// Verdict is a result together with the answer it was checked against
+type Verdict struct {
+	Result
+	Expected string
+	Status   Status
+}
+
+// Check compares each result with the expected answer for its part. Parts
+// that the puzzle does not have are left out unless an answer was recorded
+// for them.
+func Check(results []Result, expected Expected) []Verdict {
+	var verdicts []Verdict
+	for _, result := range results {
+		want, recorded := expected[result.Part]
+		if !recorded && errors.Is(result.Err, ErrNoPart) {
+			continue
+		}
+
+		verdict := Verdict{Result: result, Expected: want}
+		switch {
+		case !recorded:
+			verdict.Status = Missing
+		case result.Err == nil && result.Answer.String() == want:
+			verdict.Status = Pass
+		default:
+			verdict.Status = Fail
+		}
+		verdicts = append(verdicts, verdict)
+	}
+	return verdicts
+}
@@ -0,0 +1,102 @@
+package aoc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadExpected(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Expected
+		wantErr bool
+	}{
+		{
+			name:  "numbers and strings",
+			input: `{"part1": 1388114, "part2": "cl,df,ft"}`,
+			want:  Expected{1: "1388114", 2: "cl,df,ft"},
+		},
+		{
+			name:  "large numbers keep every digit",
+			input: `{"part2": 252442982856820}`,
+			want:  Expected{2: "252442982856820"},
+		},
+		{
+			name:    "unknown part",
+			input:   `{"part3": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "not an answer",
+			input:   `{"part1": [1, 2]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadExpected(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadExpected() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadExpected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	failure := errors.New("boom")
+	results := []Result{
+		{Part: 1, Answer: Int(42)},
+		{Part: 2, Answer: Text("a,b")},
+	}
+
+	tests := []struct {
+		name     string
+		results  []Result
+		expected Expected
+		want     []Status
+	}{
+		{
+			name:     "all match",
+			results:  results,
+			expected: Expected{1: "42", 2: "a,b"},
+			want:     []Status{Pass, Pass},
+		},
+		{
+			name:     "mismatch and missing",
+			results:  results,
+			expected: Expected{1: "41"},
+			want:     []Status{Fail, Missing},
+		},
+		{
+			name:     "solver error",
+			results:  []Result{{Part: 1, Err: failure}},
+			expected: Expected{1: "42"},
+			want:     []Status{Fail},
+		},
+		{
+			name:     "puzzle without a second part",
+			results:  []Result{{Part: 1, Answer: Int(42)}, {Part: 2, Err: ErrNoPart}},
+			expected: Expected{1: "42"},
+			want:     []Status{Pass},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Status
+			for _, verdict := range Check(tt.results, tt.expected) {
+				got = append(got, verdict.Status)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() statuses = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
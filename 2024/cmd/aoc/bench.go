@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aoc2024/aoc"
+)
+
+// benchCommand implements "aoc bench <year> <day|all>", which times every
+// phase of the selected puzzles and optionally compares the timings with,
+// or saves them as, a baseline.
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	runs := fs.Int("runs", 3, "number of runs per puzzle, keeping the fastest")
+	baselinePath := fs.String("baseline", "", "baseline file to compare the timings with")
+	savePath := fs.String("save", "", "file to save the timings to as a new baseline")
+	threshold := fs.Float64("threshold", 0.2, "slowdown against the baseline reported as a regression")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return errors.New("usage: aoc bench <year> <day|all> [-runs n] [-baseline file] [-save file] [-threshold f]")
+	}
+
+	year, err := parseYear(positional[0])
+	if err != nil {
+		return err
+	}
+	days, err := parseDays(year, positional[1])
+	if err != nil {
+		return err
+	}
+
+	var baseline aoc.Baseline
+	if *baselinePath != "" {
+		if baseline, err = loadBaseline(*baselinePath); err != nil {
+			return err
+		}
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "YEAR\tDAY\tPARSE\tPART 1\tPART 2\tTOTAL\tCHANGE\t")
+
+	var timings []aoc.Timing
+	failed, regressed := 0, 0
+	for _, day := range days {
+		timing, err := benchDay(year, day, *runs)
+		if err != nil {
+			fmt.Fprintf(table, "%d\t%d\terror: %v\t\t\t\t\t\n", year, day, err)
+			failed++
+			continue
+		}
+		timings = append(timings, timing)
+
+		change := ""
+		if base, ok := baseline.Find(year, day); ok {
+			change = formatChange(base.Total(), timing.Total())
+			if slower := aoc.Regressions(base, timing, *threshold); len(slower) > 0 {
+				change += " REGRESSION (" + strings.Join(slower, ", ") + ")"
+				regressed++
+			}
+		}
+
+		fmt.Fprintf(table, "%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", year, day,
+			formatDuration(timing.Parse), formatDuration(timing.Part1), formatDuration(timing.Part2),
+			formatDuration(timing.Total()), change)
+	}
+	table.Flush()
+
+	if *savePath != "" {
+		if err := saveBaseline(*savePath, timings); err != nil {
+			return err
+		}
+		fmt.Printf("\nSaved the timings of %d puzzles to %s\n", len(timings), *savePath)
+	}
+
+	switch {
+	case failed > 0:
+		return fmt.Errorf("%d puzzles failed", failed)
+	case regressed > 0:
+		return fmt.Errorf("%d puzzles regressed against %s", regressed, *baselinePath)
+	}
+	return nil
+}
+
+// benchDay times a single registered puzzle on its default input
+func benchDay(year, day, runs int) (aoc.Timing, error) {
+	input, err := aoc.OpenInput(year, day, "")
+	if err != nil {
+		return aoc.Timing{}, err
+	}
+	defer input.Close()
+
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return aoc.Timing{}, fmt.Errorf("reading input: %w", err)
+	}
+	return aoc.Measure(year, day, data, runs)
+}
+
+// loadBaseline reads a baseline file
+func loadBaseline(path string) (aoc.Baseline, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening baseline: %w", err)
+	}
+	defer file.Close()
+
+	return aoc.ReadBaseline(file)
+}
+
+// saveBaseline records timings in a baseline file. Puzzles that were not
+// benchmarked this time keep the timings already saved for them.
+func saveBaseline(path string, timings []aoc.Timing) error {
+	baseline, err := loadBaseline(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for _, timing := range timings {
+		baseline = baseline.Update(timing)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating baseline: %w", err)
+	}
+	if err := baseline.Write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("writing baseline: %w", err)
+	}
+	return file.Close()
+}
+
+// formatDuration rounds a duration to a readable precision, using "-" for
+// parts that do not exist.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "-"
+	case d < time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.Round(10 * time.Microsecond).String()
+	}
+}
+
+// formatChange describes how a duration compares with its baseline
+func formatChange(base, current time.Duration) string {
+	if base == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+.0f%%", (float64(current)/float64(base)-1)*100)
+}
@@ -0,0 +1,31 @@
+package main
+
+// Every puzzle package registers its solver from init, so linking it into
+// the binary is all that is needed to make it available to the runner.
+import (
+	_ "aoc2024/day-01"
+	_ "aoc2024/day-02"
+	_ "aoc2024/day-03"
+	_ "aoc2024/day-04"
+	_ "aoc2024/day-05"
+	_ "aoc2024/day-06"
+	_ "aoc2024/day-07"
+	_ "aoc2024/day-08"
+	_ "aoc2024/day-09"
+	_ "aoc2024/day-10"
+	_ "aoc2024/day-11"
+	_ "aoc2024/day-12"
+	_ "aoc2024/day-13"
+	_ "aoc2024/day-14"
+	_ "aoc2024/day-15"
+	_ "aoc2024/day-16"
+	_ "aoc2024/day-17"
+	_ "aoc2024/day-18"
+	_ "aoc2024/day-19"
+	_ "aoc2024/day-20"
+	_ "aoc2024/day-21"
+	_ "aoc2024/day-22"
+	_ "aoc2024/day-23"
+	_ "aoc2024/day-24"
+	_ "aoc2024/day-25"
+)
@@ -0,0 +1,106 @@
+// Command aoc runs the Advent of Code solutions linked into it.
+//
+// Usage:
+//
+//	aoc run <year> <day|all> [-part n] [-input path] [-format text|json]
+//	aoc verify [year] [day]
+//	aoc bench <year> <day|all> [-runs n] [-baseline file] [-save file] [-threshold f]
+//	aoc new <year> <day> [-example file]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"aoc2024/aoc"
+)
+
+const usage = `Usage:
+  aoc run <year> <day|all> [-part n] [-input path] [-format text|json]
+                           solve one or every day of a year, reading the
+                           input from a file, - for stdin, or the embedded
+                           input by default, and printing the answers as
+                           text or as one JSON record per part
+  aoc verify [year] [day]  check solvers against their answers.json
+  aoc bench <year> <day|all> [-runs n] [-baseline file] [-save file] [-threshold f]
+                           time every part, comparing with or saving a
+                           baseline of earlier timings
+  aoc new <year> <day> [-example file]
+                           create and register a day from the template
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "verify":
+		err = verifyCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "new":
+		err = newCommand(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseArgs parses flags that may appear before, between or after the
+// positional arguments, and returns the positional arguments in order.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseYear converts a year argument to an integer
+func parseYear(arg string) (int, error) {
+	year, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid year %q", arg)
+	}
+	return year, nil
+}
+
+// parseDays expands a day argument, which is either a day number or "all",
+// into the registered days it refers to.
+func parseDays(year int, arg string) ([]int, error) {
+	if arg == "all" {
+		days := aoc.Days(year)
+		if len(days) == 0 {
+			return nil, fmt.Errorf("no puzzles registered for %d", year)
+		}
+		return days, nil
+	}
+
+	day, err := strconv.Atoi(arg)
+	if err != nil || day < 1 || day > 25 {
+		return nil, fmt.Errorf("invalid day %q", arg)
+	}
+	return []int{day}, nil
+}
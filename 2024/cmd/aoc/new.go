@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"aoc2024/templates"
+)
+
+// newCommand implements "aoc new <year> <day>", which creates a day from the
+// template and links it into the runner.
+func newCommand(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	examplePath := fs.String("example", "", "file holding the puzzle's example input for the test")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return errors.New("usage: aoc new <year> <day> [-example file]")
+	}
+
+	year, err := parseYear(positional[0])
+	if err != nil {
+		return err
+	}
+	day, err := strconv.Atoi(positional[1])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q", positional[1])
+	}
+
+	var example string
+	if *examplePath != "" {
+		content, err := os.ReadFile(*examplePath)
+		if err != nil {
+			return fmt.Errorf("reading example: %w", err)
+		}
+		example = string(content)
+	}
+
+	dir, err := yearDir(year)
+	if err != nil {
+		return err
+	}
+	written, err := templates.WriteDay(dir, year, day, example)
+	if err != nil {
+		return err
+	}
+	if err := registerDay(dir, day); err != nil {
+		return err
+	}
+
+	fmt.Printf("Day %d created successfully at %s\n", day, filepath.Join(dir, templates.DayDir(day)))
+	for _, path := range written {
+		fmt.Printf("  %s\n", path)
+	}
+	fmt.Println("\nNext steps:")
+	steps := []string{
+		"Add your puzzle input to input.txt",
+		"Update README.md with the problem description",
+		"Implement the solution in solution.go",
+		fmt.Sprintf("Run with: go run ./cmd/aoc run %d %d", year, day),
+		fmt.Sprintf("Test with: go test ./%s", templates.DayDir(day)),
+	}
+	if dir != "." {
+		steps = append([]string{"cd " + dir}, steps...)
+	}
+	for i, step := range steps {
+		fmt.Printf("  %d. %s\n", i+1, step)
+	}
+	return nil
+}
+
+// yearDir finds the directory of a year's Go module, which is either the
+// current directory or the year's directory below it.
+func yearDir(year int) (string, error) {
+	module := fmt.Sprintf("module aoc%d\n", year)
+	for _, dir := range []string{".", strconv.Itoa(year)} {
+		content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil && bytes.HasPrefix(content, []byte(module)) {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("no Go module found for %d; run aoc new from the repository root or the year's directory", year)
+}
+
+// registerDay adds the blank import of a day to days.go, keeping the imports
+// sorted, so that the runner can solve it.
+func registerDay(dir string, day int) error {
+	path := filepath.Join(dir, "cmd", "aoc", "days.go")
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("registering day: %w", err)
+	}
+
+	start := bytes.Index(src, []byte("import (\n"))
+	if start < 0 {
+		return fmt.Errorf("registering day: no import block in %s", path)
+	}
+	start += len("import (\n")
+	end := start + bytes.Index(src[start:], []byte("\n)"))
+	if end < start {
+		return fmt.Errorf("registering day: unterminated import block in %s", path)
+	}
+
+	imports := strings.Split(string(src[start:end]), "\n")
+	line := fmt.Sprintf("\t_ %q", "aoc2024/"+templates.DayDir(day))
+	for _, existing := range imports {
+		if existing == line {
+			return nil
+		}
+	}
+	imports = append(imports, line)
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	buf.Write(src[:start])
+	buf.WriteString(strings.Join(imports, "\n"))
+	buf.Write(src[end:])
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("registering day: %w", err)
+	}
+	return os.WriteFile(path, formatted, 0o644)
+}
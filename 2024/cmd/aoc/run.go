@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"aoc2024/aoc"
+)
+
+// runCommand implements "aoc run <year> <day|all>"
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := fs.Int("part", 0, "part to solve (1 or 2, 0 for both)")
+	input := fs.String("input", "", "puzzle input file, or - for stdin (default: embedded input)")
+	formatName := fs.String("format", "text", "output format (text or json)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return errors.New("usage: aoc run <year> <day|all> [-part n] [-input path] [-format text|json]")
+	}
+
+	format, err := aoc.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	year, err := parseYear(positional[0])
+	if err != nil {
+		return err
+	}
+	days, err := parseDays(year, positional[1])
+	if err != nil {
+		return err
+	}
+	if *input != "" && len(days) > 1 {
+		return errors.New("-input can only be used when solving a single day")
+	}
+
+	out := aoc.NewOutput(os.Stdout, format)
+	failed := false
+	for _, day := range days {
+		results, err := aoc.Run(year, day, *part, *input)
+		if err != nil {
+			results = []aoc.Result{{Year: year, Day: day, Err: err}}
+		}
+
+		if len(days) > 1 {
+			out.Header(year, day)
+		}
+		if err := out.Write(results); err != nil {
+			return err
+		}
+		failed = failed || aoc.Failed(results)
+	}
+
+	if failed {
+		return errors.New("one or more puzzles failed")
+	}
+	return nil
+}
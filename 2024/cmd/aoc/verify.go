@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"aoc2024/aoc"
+)
+
+// verifyCommand implements "aoc verify [year] [day]", which checks every
+// selected solver against its recorded answers.
+func verifyCommand(args []string) error {
+	if len(args) > 2 {
+		return errors.New("usage: aoc verify [year] [day]")
+	}
+
+	years := aoc.Years()
+	if len(args) > 0 {
+		year, err := parseYear(args[0])
+		if err != nil {
+			return err
+		}
+		years = []int{year}
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "YEAR\tDAY\tPART\tSTATUS\tANSWER\tEXPECTED")
+
+	counts := make(map[aoc.Status]int)
+	for _, year := range years {
+		dayArg := "all"
+		if len(args) > 1 {
+			dayArg = args[1]
+		}
+		days, err := parseDays(year, dayArg)
+		if err != nil {
+			return err
+		}
+
+		for _, day := range days {
+			verdicts, err := verifyDay(year, day)
+			if err != nil {
+				fmt.Fprintf(table, "%d\t%d\t-\t%v\t%v\t\n", year, day, aoc.Fail, err)
+				counts[aoc.Fail]++
+				continue
+			}
+
+			for _, v := range verdicts {
+				answer := v.Answer.String()
+				if v.Err != nil {
+					answer = "error: " + v.Err.Error()
+				}
+				fmt.Fprintf(table, "%d\t%d\t%d\t%v\t%s\t%s\n", v.Year, v.Day, v.Part, v.Status, answer, v.Expected)
+				counts[v.Status]++
+			}
+		}
+	}
+	table.Flush()
+
+	fmt.Printf("\n%d passed, %d failed, %d missing\n", counts[aoc.Pass], counts[aoc.Fail], counts[aoc.Missing])
+	if counts[aoc.Fail] > 0 {
+		return errors.New("answers do not match")
+	}
+	return nil
+}
+
+// verifyDay solves a puzzle and checks the results against its answers file
+func verifyDay(year, day int) ([]aoc.Verdict, error) {
+	expected, err := aoc.LoadExpected(year, day)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := aoc.Run(year, day, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Check(results, expected), nil
+}
@@ -1,14 +1,16 @@
-package main
+package day01
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
 	"math/bits"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 // parseFile reads the contents of a file and returns two slices of integers
@@ -107,40 +109,37 @@ func calculateSimilarityScore(col1, col2 []int) int {
 	return similarityScore
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+//go:embed input.txt
+var input string
 
-	// Parse the file
-	column1, column2, err := parseFile(file)
-	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
-	}
+func init() {
+	aoc.Register(2024, 1, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 1, input)
+}
 
-	// Sort the columns
-	sortedCol1, sortedCol2 := sortColumns(column1, column2)
+// Solver solves day 1 using the two columns of location IDs
+type Solver struct {
+	column1, column2 []int
+}
 
-	// Print the sorted lists
-	fmt.Println("Sorted Column 1:", sortedCol1)
-	fmt.Println("Sorted Column 2:", sortedCol2)
+// Parse reads both columns and sorts them ready for pairing
+func (s *Solver) Parse(r io.Reader) error {
+	column1, column2, err := parseFile(r)
+	if err != nil {
+		return err
+	}
 
-	// Calculate paired distances
-	totalDistance, pairs := calculatePairedDistance(sortedCol1, sortedCol2)
+	s.column1, s.column2 = sortColumns(column1, column2)
+	return nil
+}
 
-	// Print paired results
-	fmt.Println("\nPaired Numbers and Their Distances:")
-	for _, pair := range pairs {
-		fmt.Printf("(%d, %d) Distance: %d\n", pair.Left, pair.Right, pair.Distance)
-	}
-	fmt.Println("\nTotal Distance:", totalDistance)
+// Part1 returns the total distance between the paired lists
+func (s *Solver) Part1() (aoc.Answer, error) {
+	totalDistance, _ := calculatePairedDistance(s.column1, s.column2)
+	return aoc.Int(totalDistance), nil
+}
 
-	// Calculate similarity score
-	similarityScore := calculateSimilarityScore(column1, column2)
-	fmt.Println("\nSimilarity Score:", similarityScore)
+// Part2 returns the similarity score of the two lists
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return aoc.Int(calculateSimilarityScore(s.column1, s.column2)), nil
 }
@@ -0,0 +1,13 @@
+// Command day02 solves Advent of Code 2024 day 2. It reads the file given
+// with -input, or standard input for "-input -", and otherwise uses the
+// puzzle input embedded in the binary.
+package main
+
+import (
+	"aoc2024/aoc"
+	_ "aoc2024/day-02"
+)
+
+func main() {
+	aoc.Main(2024, 2)
+}
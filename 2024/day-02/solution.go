@@ -1,12 +1,14 @@
-package main
+package day02
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 // parseFile reads integers from the input file into a 2D slice
@@ -138,28 +140,37 @@ func CountSafeAfterPruning(reports [][]int) int {
 	return safeReportCount
 }
 
-// Optional main function for demonstration
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 2, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 2, input)
+}
 
-	// Parse the file
-	unusualData, err := parseFile(file)
+// Solver solves day 2 using the reactor reports
+type Solver struct {
+	reports [][]int
+}
+
+// Parse reads one report of levels per line
+func (s *Solver) Parse(r io.Reader) error {
+	reports, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error:", err)
-		return
+		return err
 	}
 
-	// Count the number of safe reports
-	safeReportCount := CountSafeReports(unusualData)
-	fmt.Println("Number of safe reports:", safeReportCount)
+	s.reports = reports
+	return nil
+}
+
+// Part1 returns the number of safe reports
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(CountSafeReports(s.reports)), nil
+}
 
-	// Count the number of really safe reports
-	actuallySafeReportCount := CountSafeAfterPruning(unusualData)
-	fmt.Println("Number of safe report after adjustment:", actuallySafeReportCount)
+// Part2 returns the number of reports that are safe after removing at most
+// one level
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return aoc.Int(CountSafeAfterPruning(s.reports)), nil
 }
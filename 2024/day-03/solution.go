@@ -1,11 +1,13 @@
-package main
+package day03
 
 import (
+	_ "embed"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"regexp"
 	"strconv"
+
+	"aoc2024/aoc"
 )
 
 var (
@@ -22,16 +24,20 @@ var (
 	regexPartTwo = regexp.MustCompile(`(mul\((\d+),(\d+)\)|do\(\)|don't\(\))`)
 )
 
-func SumMultiplicationMatchesPartOne(matches [][]string) int {
+func SumMultiplicationMatchesPartOne(matches [][]string) (int, error) {
 	totalSum := 0
 	for _, match := range matches {
-		totalSum += sti(match[1]) * sti(match[2])
+		product, err := multiply(match[1], match[2])
+		if err != nil {
+			return 0, err
+		}
+		totalSum += product
 	}
 
-	return totalSum
+	return totalSum, nil
 }
 
-func SumMultiplicationMatchesPartTwo(matches [][]string) int {
+func SumMultiplicationMatchesPartTwo(matches [][]string) (int, error) {
 	enabled := true
 	total := 0
 	for _, match := range matches {
@@ -42,40 +48,82 @@ func SumMultiplicationMatchesPartTwo(matches [][]string) int {
 			enabled = false
 		default:
 			if enabled {
-				total += sti(match[2]) * sti(match[3])
+				product, err := multiply(match[2], match[3])
+				if err != nil {
+					return 0, err
+				}
+				total += product
 			}
 		}
 	}
 
-	return total
+	return total, nil
 }
 
-func sti(s string) int {
+// multiply returns the product of the two numbers of a mul instruction
+func multiply(x, y string) (int, error) {
+	a, err := sti(x)
+	if err != nil {
+		return 0, err
+	}
+	b, err := sti(y)
+	if err != nil {
+		return 0, err
+	}
+	return a * b, nil
+}
+
+// sti converts a number matched by one of the patterns. The patterns only
+// match digits, so the only possible failure is a number too large for an
+// int.
+func sti(s string) (int, error) {
 	i, err := strconv.Atoi(s)
 	if err != nil {
-		fmt.Printf("Conversion error: %v\n", err)
-		return 0
+		return 0, fmt.Errorf("mul operand: %w", err)
 	}
-	return i
+	return i, nil
+}
+
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 3, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 3, input)
 }
 
-func main() {
-	filename := "input.txt"
+// Solver solves day 3 using the corrupted memory contents
+type Solver struct {
+	memory string
+}
 
-	content, err := os.ReadFile(filename)
+// Parse reads the whole of the corrupted memory
+func (s *Solver) Parse(r io.Reader) error {
+	content, err := io.ReadAll(r)
 	if err != nil {
-		log.Fatalf("Failed to read file: %v", err)
+		return fmt.Errorf("failed to read memory: %w", err)
 	}
 
-	memory := string(content)
+	s.memory = string(content)
+	return nil
+}
 
-	// -- Part One --
-	matchesPartOne := regexPartOne.FindAllStringSubmatch(memory, -1)
-	sumPartOne := SumMultiplicationMatchesPartOne(matchesPartOne)
-	fmt.Println("Sum of all valid multiplication results for part one:", sumPartOne)
+// Part1 returns the sum of all valid multiplications
+func (s *Solver) Part1() (aoc.Answer, error) {
+	matches := regexPartOne.FindAllStringSubmatch(s.memory, -1)
+	sum, err := SumMultiplicationMatchesPartOne(matches)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(sum), nil
+}
 
-	// -- Part Two --
-	matchesPartTwo := regexPartTwo.FindAllStringSubmatch(memory, -1)
-	sumPartTwo := SumMultiplicationMatchesPartTwo(matchesPartTwo)
-	fmt.Println("Sum of all valid multiplication results for part two:", sumPartTwo)
+// Part2 returns the sum of the multiplications enabled by do() and don't()
+func (s *Solver) Part2() (aoc.Answer, error) {
+	matches := regexPartTwo.FindAllStringSubmatch(s.memory, -1)
+	sum, err := SumMultiplicationMatchesPartTwo(matches)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(sum), nil
 }
@@ -1,6 +1,8 @@
-package main
+package day03
 
 import (
+	"errors"
+	"strconv"
 	"testing"
 )
 
@@ -40,7 +42,10 @@ func TestSolveCorruptedMemoryPartOne(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			matches := regexPartOne.FindAllStringSubmatch(tc.input, -1)
-			sum := SumMultiplicationMatchesPartOne(matches)
+			sum, err := SumMultiplicationMatchesPartOne(matches)
+			if err != nil {
+				t.Fatalf("For input '%s': unexpected error %v", tc.input, err)
+			}
 			if sum != tc.expected {
 				t.Errorf("For input '%s': expected %d, got %d",
 					tc.input, tc.expected, sum)
@@ -95,7 +100,10 @@ func TestSolveCorruptedMemoryPartTwo(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			matches := regexPartTwo.FindAllStringSubmatch(tc.input, -1)
-			sum := SumMultiplicationMatchesPartTwo(matches)
+			sum, err := SumMultiplicationMatchesPartTwo(matches)
+			if err != nil {
+				t.Fatalf("For input '%s': unexpected error %v", tc.input, err)
+			}
 			if sum != tc.expected {
 				t.Errorf("For input '%s': expected %d, got %d",
 					tc.input, tc.expected, sum)
@@ -103,3 +111,11 @@ func TestSolveCorruptedMemoryPartTwo(t *testing.T) {
 		})
 	}
 }
+
+func TestSolveCorruptedMemoryOverflow(t *testing.T) {
+	input := "do()mul(2,3)mul(99999999999999999999,2)"
+	matches := regexPartTwo.FindAllStringSubmatch(input, -1)
+	if sum, err := SumMultiplicationMatchesPartTwo(matches); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("For input '%s': expected a range error, got %d, %v", input, sum, err)
+	}
+}
@@ -1,40 +1,25 @@
-package main
+package part01
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
-func FindWordOccurrences(grid [][]rune, word string) int {
-	if len(grid) == 0 || len(grid[0]) == 0 || len(word) == 0 {
+func FindWordOccurrences(g *grid.Grid[rune], word string) int {
+	if g.Width() == 0 || g.Height() == 0 || len(word) == 0 {
 		return 0
 	}
 
-	// Define the 8 possible directions (dx, dy)
-	directions := [][2]int{
-		{0, 1},   // Right
-		{0, -1},  // Left
-		{1, 0},   // Down
-		{-1, 0},  // Up
-		{1, 1},   // Down-Right
-		{1, -1},  // Down-Left
-		{-1, 1},  // Up-Right
-		{-1, -1}, // Up-Left
-	}
-
-	wordLen := len(word)
 	wordRunes := []rune(word)
-	rows := len(grid)
-	cols := len(grid[0])
 	count := 0
 
-	// Helper function to check if the word exists starting at (x, y) in a given direction
-	isValid := func(x, y, dx, dy int) bool {
-		for i := 0; i < wordLen; i++ {
-			nx, ny := x+i*dx, y+i*dy
-			if nx < 0 || ny < 0 || nx >= rows || ny >= cols || grid[nx][ny] != wordRunes[i] {
+	// Helper function to check if the word exists starting at p in a given direction
+	isValid := func(p, step grid.Point) bool {
+		for i, r := range wordRunes {
+			cell, ok := g.Get(p.Add(step.Scale(i)))
+			if !ok || cell != r {
 				return false
 			}
 		}
@@ -42,15 +27,13 @@ func FindWordOccurrences(grid [][]rune, word string) int {
 	}
 
 	// Iterate through each cell in the grid
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			// If the current cell matches the first letter of the word
-			if grid[i][j] == wordRunes[0] {
-				// Check all 8 directions
-				for _, dir := range directions {
-					if isValid(i, j, dir[0], dir[1]) {
-						count++
-					}
+	for p, cell := range g.All() {
+		// If the current cell matches the first letter of the word
+		if cell == wordRunes[0] {
+			// Check all 8 directions
+			for _, step := range grid.Offsets8 {
+				if isValid(p, step) {
+					count++
 				}
 			}
 		}
@@ -59,40 +42,23 @@ func FindWordOccurrences(grid [][]rune, word string) int {
 	return count
 }
 
-func parseFile(r io.Reader) ([][]rune, error) {
-	var grid [][]rune
-
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		lineRunes := []rune(scanner.Text())
-		grid = append(grid, lineRunes)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
-	}
-
-	return grid, nil
+// Solver solves part one of day 4 using the word search grid
+type Solver struct {
+	grid *grid.Grid[rune]
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
+// Parse reads the word search grid
+func (s *Solver) Parse(r io.Reader) error {
+	g, err := grid.Parse(r, grid.AsRune)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return err
 	}
-	defer file.Close()
 
-	// Parse the file
-	grid, err := parseFile(file)
-	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
-	}
+	s.grid = g
+	return nil
+}
 
-	// Find the word "XMAS" in the grid
-	word := "XMAS"
-	result := FindWordOccurrences(grid, word)
-	fmt.Printf("The word '%s' occurs %d times in the grid.\n", word, result)
+// Solve returns the number of times XMAS occurs in the grid
+func (s *Solver) Solve() (aoc.Answer, error) {
+	return aoc.Int(FindWordOccurrences(s.grid, "XMAS")), nil
 }
@@ -1,7 +1,9 @@
-package main
+package part01
 
 import (
 	"testing"
+
+	"aoc2024/grid"
 )
 
 func TestFindWordOccurrences(t *testing.T) {
@@ -136,7 +138,11 @@ func TestFindWordOccurrences(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := FindWordOccurrences(tc.grid, tc.word)
+			g, err := grid.FromRows(tc.grid)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			result := FindWordOccurrences(g, tc.word)
 			if result != tc.expected {
 				t.Errorf("Expected %d occurrences, got %d", tc.expected, result)
 			}
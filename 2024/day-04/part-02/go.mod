@@ -1,3 +0,0 @@
-module solution
-
-go 1.22.4
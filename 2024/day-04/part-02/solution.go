@@ -1,10 +1,10 @@
-package main
+package part02
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
 // Pattern represents a 3x3 grid search pattern
@@ -33,11 +33,12 @@ var validPatterns = []Pattern{
 	},
 }
 
-// matchPattern checks if a 3x3 grid matches a pattern template
-func matchPattern(grid [][]rune, x, y int, pattern Pattern) bool {
+// matchPattern checks if the 3x3 block with its top-left corner at p
+// matches a pattern template
+func matchPattern(g *grid.Grid[rune], p grid.Point, pattern Pattern) bool {
 	for i := 0; i < 3; i++ {
 		for j := 0; j < 3; j++ {
-			gridCell := grid[x+i][y+j]
+			gridCell := g.At(grid.Point{X: p.X + j, Y: p.Y + i})
 			patternCell := pattern[i][j]
 
 			// Skip dot (wildcard) matches
@@ -54,17 +55,17 @@ func matchPattern(grid [][]rune, x, y int, pattern Pattern) bool {
 	return true
 }
 
-func countXmasGrids(grid [][]rune) int {
+func countXmasGrids(g *grid.Grid[rune]) int {
 	count := 0
-	for x := 0; x <= len(grid)-3; x++ {
-		for y := 0; y <= len(grid[0])-3; y++ {
+	for y := 0; y <= g.Height()-3; y++ {
+		for x := 0; x <= g.Width()-3; x++ {
 			// Center cell must be 'A'
-			if grid[x+1][y+1] != 'A' {
+			if g.At(grid.Point{X: x + 1, Y: y + 1}) != 'A' {
 				continue
 			}
 
 			for _, pattern := range validPatterns {
-				if matchPattern(grid, x, y, pattern) {
+				if matchPattern(g, grid.Point{X: x, Y: y}, pattern) {
 					count++
 				}
 			}
@@ -73,38 +74,23 @@ func countXmasGrids(grid [][]rune) int {
 	return count
 }
 
-func parseFile(r io.Reader) ([][]rune, error) {
-	var grid [][]rune
-
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		lineRunes := []rune(scanner.Text())
-		grid = append(grid, lineRunes)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
-	}
-
-	return grid, nil
+// Solver solves part two of day 4 using the word search grid
+type Solver struct {
+	grid *grid.Grid[rune]
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
+// Parse reads the word search grid
+func (s *Solver) Parse(r io.Reader) error {
+	g, err := grid.Parse(r, grid.AsRune)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return err
 	}
-	defer file.Close()
 
-	// Parse the file
-	grid, err := parseFile(file)
-	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
-	}
+	s.grid = g
+	return nil
+}
 
-	xmasGrids := countXmasGrids(grid)
-	fmt.Printf("Pattern Matches: %d\n", xmasGrids)
+// Solve returns the number of X-MAS patterns in the grid
+func (s *Solver) Solve() (aoc.Answer, error) {
+	return aoc.Int(countXmasGrids(s.grid)), nil
 }
@@ -1,7 +1,9 @@
-package main
+package part02
 
 import (
 	"testing"
+
+	"aoc2024/grid"
 )
 
 // Test the first scenario with the provided 10x10 grid
@@ -83,7 +85,11 @@ func TestCountXmasGridsWithLargeGrid(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := countXmasGrids(tc.grid)
+			g, err := grid.FromRows(tc.grid)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			result := countXmasGrids(g)
 			if result != tc.expected {
 				t.Errorf("Expected %d occurrences, got %d", tc.expected, result)
 			}
@@ -1,12 +1,14 @@
-package main
+package day05
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 type PageOrderChecker struct {
@@ -130,34 +132,39 @@ func convertStringsToInts(strings []string) ([]int, error) {
 	return ints, nil
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
-
-	// Parse the file
-	rules, updates, err := parseFile(file)
-	if err != nil {
-		fmt.Println("Error reading file:", err)
-		return
-	}
-
+// buildChecker creates a PageOrderChecker from rules like "47|53"
+func buildChecker(rules []string) (*PageOrderChecker, error) {
 	poc := NewPageOrderChecker()
-
-	// Add rules
 	for _, rule := range rules {
 		parts := strings.Split(rule, "|")
-		before, _ := strconv.Atoi(parts[0])
-		after, _ := strconv.Atoi(parts[1])
-		poc.AddRule(before, after)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid rule: %s", rule)
+		}
+
+		pages, err := convertStringsToInts(parts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %v", rule, err)
+		}
+		poc.AddRule(pages[0], pages[1])
 	}
+	return poc, nil
+}
 
-	// Check updates and sum middle pages
-	var middlePages []int
+// sumValidMiddlePages sums the middle page of every update already in order
+func sumValidMiddlePages(poc *PageOrderChecker, updates [][]int) int {
+	sum := 0
+	for _, update := range updates {
+		if poc.IsValidOrder(update) {
+			sum += update[len(update)/2]
+		}
+	}
+	return sum
+}
+
+// sumReorderedMiddlePages sums the middle page of every update that had to
+// be reordered
+func sumReorderedMiddlePages(poc *PageOrderChecker, updates [][]int) int {
+	sum := 0
 	for _, update := range updates {
 		// If not already in valid order, try to reorder
 		if !poc.IsValidOrder(update) {
@@ -165,16 +172,50 @@ func main() {
 
 			// Only consider if reordering was successful
 			if poc.IsValidOrder(reorderedUpdate) {
-				middlePage := reorderedUpdate[len(reorderedUpdate)/2]
-				middlePages = append(middlePages, middlePage)
+				sum += reorderedUpdate[len(reorderedUpdate)/2]
 			}
 		}
 	}
+	return sum
+}
 
-	// Calculate sum of middle pages
-	sum := 0
-	for _, page := range middlePages {
-		sum += page
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 5, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 5, input)
+}
+
+// Solver solves day 5 using the page ordering rules and updates
+type Solver struct {
+	checker *PageOrderChecker
+	updates [][]int
+}
+
+// Parse reads the ordering rules followed by the updates
+func (s *Solver) Parse(r io.Reader) error {
+	rules, updates, err := parseFile(r)
+	if err != nil {
+		return err
 	}
-	fmt.Println("Sum of middle pages:", sum)
+
+	checker, err := buildChecker(rules)
+	if err != nil {
+		return err
+	}
+
+	s.checker = checker
+	s.updates = updates
+	return nil
+}
+
+// Part1 returns the sum of the middle pages of correctly ordered updates
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(sumValidMiddlePages(s.checker, s.updates)), nil
+}
+
+// Part2 returns the sum of the middle pages of the reordered updates
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return aoc.Int(sumReorderedMiddlePages(s.checker, s.updates)), nil
 }
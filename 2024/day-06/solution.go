@@ -1,21 +1,14 @@
-package main
+package day06
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
-	"os"
 	"strings"
-)
-
-// Direction represents the cardinal directions
-type Direction int
 
-const (
-	Up Direction = iota
-	Right
-	Down
-	Left
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
 func parseFile(r io.Reader) ([]string, error) {
@@ -26,6 +19,9 @@ func parseFile(r io.Reader) ([]string, error) {
 	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := strings.Trim(scanner.Text(), `" \t`) // Trim quotes, spaces, and tabs
+		if line == "" {
+			continue
+		}
 		lines = append(lines, line)
 	}
 
@@ -38,94 +34,79 @@ func parseFile(r io.Reader) ([]string, error) {
 
 // Map represents the laboratory map
 type Map struct {
-	grid       [][]rune
-	GuardX     int
-	GuardY     int
-	GuardDir   Direction
-	visitedPos map[string]bool
+	grid       *grid.Grid[rune]
+	Guard      grid.Point
+	GuardDir   grid.Direction
+	visitedPos map[grid.Point]bool
+}
+
+// guardState is a position and heading of the guard, used to detect loops
+type guardState struct {
+	pos grid.Point
+	dir grid.Direction
 }
 
 // NewMap creates a new Map from input strings
-func NewMap(mapInput []string) *Map {
-	m := &Map{
-		grid:       make([][]rune, len(mapInput)),
-		visitedPos: make(map[string]bool),
+func NewMap(mapInput []string) (*Map, error) {
+	g, err := grid.FromLines(mapInput, grid.AsRune)
+	if err != nil {
+		return nil, fmt.Errorf("parsing map: %w", err)
 	}
 
-	// Convert input to 2D rune grid and find guard's initial position
-	for y, row := range mapInput {
-		m.grid[y] = []rune(row)
-		for x, cell := range row {
-			switch cell {
-			case '^':
-				m.GuardX = x
-				m.GuardY = y
-				m.GuardDir = Up
-			}
-		}
+	m := &Map{
+		grid:       g,
+		visitedPos: make(map[grid.Point]bool),
 	}
 
+	// Find the guard's initial position
+	m.Guard, m.GuardDir = m.findGuardStartingPosition()
+
 	// Mark starting position as visited
-	m.markVisited(m.GuardX, m.GuardY)
+	m.markVisited(m.Guard)
 
-	return m
+	return m, nil
 }
 
 // isObstacleAhead checks if there's an obstacle in the guard's current direction
 func (m *Map) isObstacleAhead() bool {
-	nextX, nextY := m.getNextPosition()
-	return m.isOutOfBounds(nextX, nextY) || m.grid[nextY][nextX] == '#'
+	cell, ok := m.grid.Get(m.getNextPosition())
+	return !ok || cell == '#'
 }
 
 // getNextPosition calculates the next position based on current direction
-func (m *Map) getNextPosition() (int, int) {
-	switch m.GuardDir {
-	case Up:
-		return m.GuardX, m.GuardY - 1
-	case Right:
-		return m.GuardX + 1, m.GuardY
-	case Down:
-		return m.GuardX, m.GuardY + 1
-	case Left:
-		return m.GuardX - 1, m.GuardY
-	}
-	return m.GuardX, m.GuardY
+func (m *Map) getNextPosition() grid.Point {
+	return m.Guard.Move(m.GuardDir)
 }
 
-// isOutOfBounds checks if the given coordinates are outside the map
-func (m *Map) isOutOfBounds(x, y int) bool {
-	return x < 0 || y < 0 || y >= len(m.grid) || x >= len(m.grid[y])
-}
-
-// markVisited adds the current position to visited positions
-func (m *Map) markVisited(x, y int) {
-	m.visitedPos[positionKey(x, y)] = true
+// markVisited adds the given position to visited positions
+func (m *Map) markVisited(p grid.Point) {
+	m.visitedPos[p] = true
 }
 
 // turnRight rotates the guard's direction 90 degrees clockwise
 func (m *Map) turnRight() {
-	m.GuardDir = (m.GuardDir + 1) % 4
+	m.GuardDir = m.GuardDir.Clockwise()
 }
 
 // move advances the guard one step in the current direction
 func (m *Map) move() {
-	m.GuardX, m.GuardY = m.getNextPosition()
-	m.markVisited(m.GuardX, m.GuardY)
+	m.Guard = m.getNextPosition()
+	m.markVisited(m.Guard)
 }
 
 // SimulateGuardPatrol runs the guard's patrol simulation
 func (m *Map) SimulateGuardPatrol() int {
 	for {
 		// Check if the next move is possible
-		nextX, nextY := m.getNextPosition()
+		next, ok := m.grid.Get(m.getNextPosition())
 
 		// Terminate if next move would be out of bounds
-		if m.isOutOfBounds(nextX, nextY) {
+		if !ok {
 			break
 		}
 
 		// If obstacle ahead, turn right
-		if m.grid[nextY][nextX] == '#' {
+		if next == '#' {
 			m.turnRight()
 			continue
 		}
@@ -137,60 +118,49 @@ func (m *Map) SimulateGuardPatrol() int {
 	return len(m.visitedPos)
 }
 
-// Helper function to create a unique key for visited positions
-func positionKey(x, y int) string {
-	return string(rune(x)) + "," + string(rune(y))
-}
+func (m *Map) FindLoopObstructionPositions() []grid.Point {
+	loopPositions := []grid.Point{}
 
-func (m *Map) FindLoopObstructionPositions() []string {
-	loopPositions := []string{}
-
-	for y := 0; y < len(m.grid); y++ {
-		row := m.grid[y] // Ensure the row exists
-		for x := 0; x < len(row); x++ {
-
-			if m.grid[y][x] == '#' {
-				continue
-			}
-
-			// Temporarily place an obstruction
-			originalCell := m.grid[y][x]
-			m.grid[y][x] = '#'
+	for p, cell := range m.grid.All() {
+		if cell == '#' {
+			continue
+		}
 
-			// Check for a loop
-			if m.simulateAndDetectLoop() {
-				loopPositions = append(loopPositions, positionKey(x, y))
-				// fmt.Printf("Loop detected with obstruction at (%d, %d)\n", x, y)
-			}
+		// Temporarily place an obstruction
+		m.grid.Set(p, '#')
 
-			// Restore the original map cell
-			m.grid[y][x] = originalCell
+		// Check for a loop
+		if m.simulateAndDetectLoop() {
+			loopPositions = append(loopPositions, p)
 		}
+
+		// Restore the original map cell
+		m.grid.Set(p, cell)
 	}
 
 	return loopPositions
 }
 
 func (m *Map) simulateAndDetectLoop() bool {
-	visitedStates := map[string]bool{}
-	m.GuardX, m.GuardY, m.GuardDir = m.findGuardStartingPosition()
+	visitedStates := map[guardState]bool{}
+	m.Guard, m.GuardDir = m.findGuardStartingPosition()
 
 	for {
-		stateKey := fmt.Sprintf("%d,%d,%d", m.GuardX, m.GuardY, m.GuardDir)
+		state := guardState{m.Guard, m.GuardDir}
 
-		if visitedStates[stateKey] {
+		if visitedStates[state] {
 			return true
 		}
 
-		visitedStates[stateKey] = true
+		visitedStates[state] = true
 
-		nextX, nextY := m.getNextPosition()
+		next, ok := m.grid.Get(m.getNextPosition())
 
-		if m.isOutOfBounds(nextX, nextY) {
+		if !ok {
 			break
 		}
 
-		if m.grid[nextY][nextX] == '#' {
+		if next == '#' {
 			m.turnRight()
 			continue
 		}
@@ -202,47 +172,50 @@ func (m *Map) simulateAndDetectLoop() bool {
 }
 
 // findGuardStartingPosition resets and finds the guard's starting position and direction
-func (m *Map) findGuardStartingPosition() (int, int, Direction) {
-	for y := 0; y < len(m.grid); y++ {
-		for x := 0; x < len(m.grid[y]); x++ {
-			switch m.grid[y][x] {
-			case '^':
-				return x, y, Up
-			}
-		}
-	}
-	return 0, 0, Up // Default fallback (shouldn't happen with valid maps)
+func (m *Map) findGuardStartingPosition() (grid.Point, grid.Direction) {
+	start, _ := m.grid.IndexFunc(func(cell rune) bool { return cell == '^' })
+	return start, grid.North // Falls back to the origin (shouldn't happen with valid maps)
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+//go:embed input.txt
+var input string
 
-	lines, err := parseFile(file)
-	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
-	}
+func init() {
+	aoc.Register(2024, 6, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 6, input)
+}
 
-	// Create the map
-	m := NewMap(lines)
+// Solver solves day 6 using the laboratory map
+type Solver struct {
+	lines []string
+}
 
-	// Simulate the guard's patrol
-	visitedPositions := m.SimulateGuardPatrol()
+// Parse reads the rows of the laboratory map
+func (s *Solver) Parse(r io.Reader) error {
+	lines, err := parseFile(r)
+	if err != nil {
+		return err
+	}
 
-	// Print the results
-	fmt.Printf("Total unique positions visited: %d\n", visitedPositions)
-	fmt.Printf("Guard's final position: (%d, %d)\n", m.GuardX, m.GuardY)
-	fmt.Printf("Guard's final direction: %v\n\n", m.GuardDir)
+	s.lines = lines
+	return nil
+}
 
-	// Find obstruction positions
-	loopPositions := m.FindLoopObstructionPositions()
+// Part1 returns the number of distinct positions the guard visits
+func (s *Solver) Part1() (aoc.Answer, error) {
+	m, err := NewMap(s.lines)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(m.SimulateGuardPatrol()), nil
+}
 
-	// Print results
-	fmt.Printf("Total positions to cause a loop: %d\n", len(loopPositions))
+// Part2 returns the number of obstruction positions that trap the guard in
+// a loop
+func (s *Solver) Part2() (aoc.Answer, error) {
+	m, err := NewMap(s.lines)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(len(m.FindLoopObstructionPositions())), nil
 }
@@ -1,7 +1,9 @@
-package main
+package day06
 
 import (
 	"testing"
+
+	"aoc2024/grid"
 )
 
 // TestParseMap tests the initial map parsing functionality
@@ -11,7 +13,7 @@ func TestParseMap(t *testing.T) {
 		inputMap       []string
 		expectedStartX int
 		expectedStartY int
-		expectedDir    Direction
+		expectedDir    grid.Direction
 	}{
 		{
 			name: "Simple Map with Up-Facing Guard",
@@ -29,7 +31,7 @@ func TestParseMap(t *testing.T) {
 			},
 			expectedStartX: 4,
 			expectedStartY: 6,
-			expectedDir:    Up,
+			expectedDir:    grid.North,
 		},
 		{
 			name: "Map with Guard in Different Position",
@@ -40,20 +42,23 @@ func TestParseMap(t *testing.T) {
 			},
 			expectedStartX: 4,
 			expectedStartY: 1,
-			expectedDir:    Up,
+			expectedDir:    grid.North,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := NewMap(tc.inputMap)
+			m, err := NewMap(tc.inputMap)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 
-			if m.GuardX != tc.expectedStartX {
-				t.Errorf("Expected guard X position %d, got %d", tc.expectedStartX, m.GuardX)
+			if m.Guard.X != tc.expectedStartX {
+				t.Errorf("Expected guard X position %d, got %d", tc.expectedStartX, m.Guard.X)
 			}
 
-			if m.GuardY != tc.expectedStartY {
-				t.Errorf("Expected guard Y position %d, got %d", tc.expectedStartY, m.GuardY)
+			if m.Guard.Y != tc.expectedStartY {
+				t.Errorf("Expected guard Y position %d, got %d", tc.expectedStartY, m.Guard.Y)
 			}
 
 			if m.GuardDir != tc.expectedDir {
@@ -99,7 +104,10 @@ func TestGuardMovement(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := NewMap(tc.inputMap)
+			m, err := NewMap(tc.inputMap)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 			visits := m.SimulateGuardPatrol()
 
 			if visits != tc.expectedVisits {
@@ -114,7 +122,7 @@ func TestObstacleDetection(t *testing.T) {
 	testCases := []struct {
 		name             string
 		inputMap         []string
-		expectedFinalDir Direction
+		expectedFinalDir grid.Direction
 	}{
 		{
 			name: "Obstacle Ahead Causes Turn",
@@ -123,7 +131,7 @@ func TestObstacleDetection(t *testing.T) {
 				"..^......",
 				"..#......",
 			},
-			expectedFinalDir: Right,
+			expectedFinalDir: grid.East,
 		},
 		{
 			name: "Multiple Obstacle Turns",
@@ -132,13 +140,16 @@ func TestObstacleDetection(t *testing.T) {
 				"..^......",
 				".........",
 			},
-			expectedFinalDir: Right,
+			expectedFinalDir: grid.East,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := NewMap(tc.inputMap)
+			m, err := NewMap(tc.inputMap)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 			m.SimulateGuardPatrol()
 
 			if m.GuardDir != tc.expectedFinalDir {
@@ -175,7 +186,10 @@ func TestFindLoopObstructionPositions(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := NewMap(tc.inputMap)
+			m, err := NewMap(tc.inputMap)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 			loopPositions := m.FindLoopObstructionPositions()
 
 			if len(loopPositions) != tc.expectedLoops {
@@ -1,13 +1,15 @@
-package main
+package day07
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
 	"math"
-	"os"
 	"strconv"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 // Helper function to evaluate a sequence with given operators
@@ -27,17 +29,31 @@ func evaluate(numbers []int, operators []rune) int {
 	return result
 }
 
+var (
+	// Operators available in part one
+	basicOperators = []rune{'+', '*'}
+
+	// Operators available in part two, including concatenation
+	allOperators = []rune{'+', '*', '|'}
+)
+
 func generateOperatorCombinations(n int) [][]rune {
+	return generateCombinations(n, allOperators)
+}
+
+// generateCombinations returns every sequence of n operators drawn from
+// the given set
+func generateCombinations(n int, operators []rune) [][]rune {
 	combinations := [][]rune{}
-	operators := []rune{'+', '*', '|'}    // Added concatenation operator
-	total := int(math.Pow(3, float64(n))) // 3^n combinations
+	base := len(operators)
+	total := int(math.Pow(float64(base), float64(n))) // base^n combinations
 	for i := 0; i < total; i++ {
 		combination := []rune{}
 		temp := i
 		for j := 0; j < n; j++ {
-			opIndex := temp % 3
+			opIndex := temp % base
 			combination = append(combination, operators[opIndex])
-			temp /= 3
+			temp /= base
 		}
 		combinations = append(combinations, combination)
 	}
@@ -46,15 +62,21 @@ func generateOperatorCombinations(n int) [][]rune {
 
 // Function to determine solvable equations and calculate the total calibration result
 func calculateCalibrationResult(equations map[int][]int) int {
+	return calibrate(equations, allOperators)
+}
+
+// calibrate sums the test values of the equations that can be solved with
+// the given operators
+func calibrate(equations map[int][]int, operators []rune) int {
 	totalSum := 0
 
 	for testValue, numbers := range equations {
 		n := len(numbers) - 1 // Number of operator positions
-		operators := generateOperatorCombinations(n)
+		combinations := generateCombinations(n, operators)
 		isSolvable := false
 
 		// Check all operator combinations
-		for _, ops := range operators {
+		for _, ops := range combinations {
 			if evaluate(numbers, ops) == testValue {
 				isSolvable = true
 				break
@@ -111,23 +133,36 @@ func parseInputFile(r io.Reader) (map[int][]int, error) {
 	return equations, nil
 }
 
-// Main function
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 7, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 7, input)
+}
+
+// Solver solves day 7 using the calibration equations
+type Solver struct {
+	equations map[int][]int
+}
 
-	// Parse the input file
-	equations, err := parseInputFile(file)
+// Parse reads one equation per line
+func (s *Solver) Parse(r io.Reader) error {
+	equations, err := parseInputFile(r)
 	if err != nil {
-		fmt.Println("Error parsing input file:", err)
-		return
+		return err
 	}
 
-	result := calculateCalibrationResult(equations)
-	fmt.Printf("Total Calibration Result: %d\n", result)
+	s.equations = equations
+	return nil
+}
+
+// Part1 returns the calibration result using addition and multiplication
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(calibrate(s.equations, basicOperators)), nil
+}
+
+// Part2 returns the calibration result including concatenation
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return aoc.Int(calculateCalibrationResult(s.equations)), nil
 }
@@ -1,69 +1,41 @@
-package main
+package part01
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
-func main() {
-	// Read the input map from file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+// Solver solves part one of day 8 using the antenna map
+type Solver struct {
+	grid *grid.Grid[rune]
+}
 
-	// Parse the input map
-	input, err := parseFile(file)
+// Parse reads the rows of the antenna map
+func (s *Solver) Parse(r io.Reader) error {
+	g, err := grid.Parse(r, grid.AsRune)
 	if err != nil {
-		fmt.Println("Error reading input file:", err)
-		return
+		return err
 	}
 
-	// Calculate map dimensions
-	mapHeight := len(input)
-	mapWidth := 0
-	if mapHeight > 0 {
-		mapWidth = len(input[0])
-	}
-
-	// Parse the input map
-	antennas := parseMap(input)
-
-	// Find all unique antinodes
-	antinodes := findAntinodes(antennas, mapWidth, mapHeight)
-
-	// Print the result
-	fmt.Println("Unique antinode locations:", len(antinodes))
+	s.grid = g
+	return nil
 }
 
-// Reads the input map from a text file
-func parseFile(r io.Reader) ([]string, error) {
-	var lines []string
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return lines, nil
+// Solve returns the number of unique antinode locations within the map
+func (s *Solver) Solve() (aoc.Answer, error) {
+	antennas := parseMap(s.grid)
+	return aoc.Int(len(findAntinodes(antennas, s.grid))), nil
 }
 
 // Parse the input map into a frequency-to-positions mapping
-func parseMap(input []string) map[rune][][2]int {
-	antennas := make(map[rune][][2]int)
+func parseMap(g *grid.Grid[rune]) map[rune][]grid.Point {
+	antennas := make(map[rune][]grid.Point)
 
-	for x, row := range input {
-		for y, char := range row {
-			if char != '.' {
-				antennas[char] = append(antennas[char], [2]int{x, y})
-			}
+	for p, char := range g.All() {
+		if char != '.' {
+			antennas[char] = append(antennas[char], p)
 		}
 	}
 
@@ -71,8 +43,8 @@ func parseMap(input []string) map[rune][][2]int {
 }
 
 // Find all unique antinodes in the map
-func findAntinodes(antennas map[rune][][2]int, mapWidth, mapHeight int) map[[2]int]bool {
-	antinodes := make(map[[2]int]bool)
+func findAntinodes(antennas map[rune][]grid.Point, g *grid.Grid[rune]) map[grid.Point]bool {
+	antinodes := make(map[grid.Point]bool)
 
 	for _, positions := range antennas {
 		// Check all pairs of antennas with the same frequency
@@ -81,7 +53,7 @@ func findAntinodes(antennas map[rune][][2]int, mapWidth, mapHeight int) map[[2]i
 				p1, p2 := positions[i], positions[j]
 
 				// Check if they create valid antinodes
-				addAntinodes(p1, p2, antinodes, mapWidth, mapHeight)
+				addAntinodes(p1, p2, antinodes, g)
 			}
 		}
 	}
@@ -90,20 +62,17 @@ func findAntinodes(antennas map[rune][][2]int, mapWidth, mapHeight int) map[[2]i
 }
 
 // Add valid antinodes created by a pair of antennas to the set
-func addAntinodes(p1, p2 [2]int, antinodes map[[2]int]bool, mapWidth, mapHeight int) {
-	// Calculate midpoints for potential antinodes
-	x1, y1 := p1[0], p1[1]
-	x2, y2 := p2[0], p2[1]
-
-	// Midpoints to check (in both directions)
-	candidates := [][2]int{
-		{2*x1 - x2, 2*y1 - y2},
-		{2*x2 - x1, 2*y2 - y1},
+func addAntinodes(p1, p2 grid.Point, antinodes map[grid.Point]bool, g *grid.Grid[rune]) {
+	// Points to check (in both directions), one antenna spacing beyond each end
+	offset := p2.Sub(p1)
+	candidates := []grid.Point{
+		p1.Sub(offset),
+		p2.Add(offset),
 	}
 
 	// Add valid antinodes within bounds
 	for _, c := range candidates {
-		if c[0] >= 0 && c[0] < mapHeight && c[1] >= 0 && c[1] < mapWidth {
+		if g.InBounds(c) {
 			antinodes[c] = true
 		}
 	}
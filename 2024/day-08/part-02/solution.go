@@ -1,65 +1,41 @@
-package main
+package part02
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
-func main() {
-	// Read the input map from file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+// Solver solves part two of day 8 using the antenna map
+type Solver struct {
+	grid *grid.Grid[rune]
+}
 
-	// Parse the input map
-	input, err := parseFile(file)
+// Parse reads the rows of the antenna map
+func (s *Solver) Parse(r io.Reader) error {
+	g, err := grid.Parse(r, grid.AsRune)
 	if err != nil {
-		fmt.Println("Error reading input file:", err)
-		return
+		return err
 	}
 
-	// Calculate map dimensions
-	mapHeight := len(input)
-	mapWidth := 0
-	if mapHeight > 0 {
-		mapWidth = len(input[0])
-	}
-
-	// Parse the input map
-	antennas := parseMap(input)
-
-	// Find all unique antinodes
-	antinodes := findAntinodes(antennas, mapWidth, mapHeight)
-
-	// Print the result
-	fmt.Println("Unique antinode locations:", len(antinodes))
+	s.grid = g
+	return nil
 }
 
-// Reads the input map from a text file
-func parseFile(r io.Reader) ([]string, error) {
-	var lines []string
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	return lines, scanner.Err()
+// Solve returns the number of unique antinode locations within the map
+func (s *Solver) Solve() (aoc.Answer, error) {
+	antennas := parseMap(s.grid)
+	return aoc.Int(len(findAntinodes(antennas, s.grid))), nil
 }
 
 // Parse the input map into a frequency-to-positions mapping
-func parseMap(input []string) map[rune][][2]int {
-	antennas := make(map[rune][][2]int)
+func parseMap(g *grid.Grid[rune]) map[rune][]grid.Point {
+	antennas := make(map[rune][]grid.Point)
 
-	for x, row := range input {
-		for y, char := range row {
-			if char != '.' {
-				antennas[char] = append(antennas[char], [2]int{x, y})
-			}
+	for p, char := range g.All() {
+		if char != '.' {
+			antennas[char] = append(antennas[char], p)
 		}
 	}
 
@@ -67,14 +43,14 @@ func parseMap(input []string) map[rune][][2]int {
 }
 
 // Find all unique antinodes in the map
-func findAntinodes(antennas map[rune][][2]int, mapWidth, mapHeight int) map[[2]int]bool {
-	antinodes := make(map[[2]int]bool)
+func findAntinodes(antennas map[rune][]grid.Point, g *grid.Grid[rune]) map[grid.Point]bool {
+	antinodes := make(map[grid.Point]bool)
 
 	for _, positions := range antennas {
 		// Check all pairs of positions for the same frequency
 		for i := 0; i < len(positions); i++ {
 			for j := i + 1; j < len(positions); j++ {
-				findAntinodesForPair(positions[i], positions[j], antinodes, mapWidth, mapHeight)
+				findAntinodesForPair(positions[i], positions[j], antinodes, g)
 			}
 		}
 	}
@@ -83,23 +59,18 @@ func findAntinodes(antennas map[rune][][2]int, mapWidth, mapHeight int) map[[2]i
 }
 
 // Find antinodes for a pair of antenna positions
-func findAntinodesForPair(p1, p2 [2]int, antinodes map[[2]int]bool, mapWidth, mapHeight int) {
-	x1, y1 := p1[0], p1[1]
-	x2, y2 := p2[0], p2[1]
-
+func findAntinodesForPair(p1, p2 grid.Point, antinodes map[grid.Point]bool, g *grid.Grid[rune]) {
 	// Include the original antenna positions in antinodes if they form a line
-	for x := 0; x < mapHeight; x++ {
-		for y := 0; y < mapWidth; y++ {
-			// Check if the point forms a line with the two antenna positions
-			if isInLine(x1, y1, x2, y2, x, y) {
-				antinodes[[2]int{x, y}] = true
-			}
+	for p := range g.Points() {
+		// Check if the point forms a line with the two antenna positions
+		if isInLine(p1, p2, p) {
+			antinodes[p] = true
 		}
 	}
 }
 
-// Check if a point (x,y) is in the same line as the line through (x1,y1) and (x2,y2)
-func isInLine(x1, y1, x2, y2, x, y int) bool {
+// Check if a point p is in the same line as the line through p1 and p2
+func isInLine(p1, p2, p grid.Point) bool {
 	// Cross product method to check colinearity
-	return (y2-y1)*(x-x1) == (y-y1)*(x2-x1)
+	return (p2.Y-p1.Y)*(p.X-p1.X) == (p.Y-p1.Y)*(p2.X-p1.X)
 }
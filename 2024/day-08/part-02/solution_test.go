@@ -1,7 +1,9 @@
-package main
+package part02
 
 import (
 	"testing"
+
+	"aoc2024/grid"
 )
 
 func TestFindAntinodes(t *testing.T) {
@@ -37,10 +39,17 @@ func TestFindAntinodes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Parse the input map
-			antennas := parseMap(tt.inputMap)
+			g, err := grid.FromLines(tt.inputMap, grid.AsRune)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if g.Width() != tt.mapWidth || g.Height() != tt.mapHeight {
+				t.Fatalf("Expected a %dx%d map, got %dx%d", tt.mapWidth, tt.mapHeight, g.Width(), g.Height())
+			}
+			antennas := parseMap(g)
 
 			// Calculate antinodes
-			result := findAntinodes(antennas, tt.mapWidth, tt.mapHeight)
+			result := findAntinodes(antennas, g)
 
 			// Verify the result
 			if len(result) != tt.expected {
@@ -1,11 +1,12 @@
-package main
+package part01
 
 import (
 	"bufio"
-	"fmt"
+	"errors"
 	"io"
-	"os"
 	"strconv"
+
+	"aoc2024/aoc"
 )
 
 func parseFile(r io.Reader) ([]string, error) {
@@ -109,18 +110,30 @@ func solve(input []string) (output string) {
 	return strconv.Itoa(checksum(register))
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
+// Solver solves part one of day 9 using the disk map
+type Solver struct {
+	input []string
+}
+
+// Parse reads the disk map
+func (s *Solver) Parse(r io.Reader) error {
+	input, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return err
+	}
+	if len(input) == 0 {
+		return errors.New("empty disk map")
 	}
-	defer file.Close()
 
-	// parse the file
-	input, err := parseFile(file)
+	s.input = input
+	return nil
+}
 
-	solution := solve(input)
-	fmt.Println(solution)
+// Solve returns the filesystem checksum after moving individual blocks
+func (s *Solver) Solve() (aoc.Answer, error) {
+	checksum, err := strconv.Atoi(solve(s.input))
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(checksum), nil
 }
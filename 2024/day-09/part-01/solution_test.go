@@ -1,4 +1,4 @@
-package main
+package part01
 
 import "testing"
 
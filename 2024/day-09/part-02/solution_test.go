@@ -1,4 +1,4 @@
-package main
+package part02
 
 import "testing"
 
@@ -0,0 +1,51 @@
+// Package day09 combines the two halves of day 9, which live in their own
+// packages under part-01 and part-02.
+package day09
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+
+	"aoc2024/aoc"
+	part01 "aoc2024/day-09/part-01"
+	part02 "aoc2024/day-09/part-02"
+)
+
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 9, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 9, input)
+}
+
+// Solver solves day 9 by delegating to the solver of each part
+type Solver struct {
+	partOne part01.Solver
+	partTwo part02.Solver
+}
+
+// Parse hands a copy of the input to each part
+func (s *Solver) Parse(r io.Reader) error {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading input: %w", err)
+	}
+
+	if err := s.partOne.Parse(bytes.NewReader(input)); err != nil {
+		return err
+	}
+	return s.partTwo.Parse(bytes.NewReader(input))
+}
+
+// Part1 returns the checksum after compacting individual blocks
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return s.partOne.Solve()
+}
+
+// Part2 returns the checksum after compacting whole files
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return s.partTwo.Solve()
+}
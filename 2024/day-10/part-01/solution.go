@@ -1,88 +1,58 @@
-package main
+package part01
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
-	"strconv"
-)
-
-type Position struct {
-	row, col int
-}
-
-func parseFile(r io.Reader) ([][]int, error) {
-	var result [][]int
-	scanner := bufio.NewScanner(r)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		var row []int
-		for _, char := range line {
-			num, err := strconv.Atoi(string(char))
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse character %c: %v", char, err)
-			}
-			row = append(row, num)
-		}
-		result = append(result, row)
-	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
+	"aoc2024/aoc"
+	"aoc2024/grid"
+)
 
-	return result, nil
-}
+// impassable marks tiles of hand-drawn maps that cannot be walked on
+const impassable = '.'
 
-func CalculateTrailheadScores(grid [][]int) int {
+func CalculateTrailheadScores(g *grid.Grid[int]) int {
 	// Find all trailheads (positions with height 0)
-	trailheads := findTrailheads(grid)
+	trailheads := findTrailheads(g)
 
 	// Calculate scores for each trailhead
 	totalScore := 0
 	for _, trailhead := range trailheads {
-		totalScore += calculateTrailheadScore(grid, trailhead)
+		totalScore += calculateTrailheadScore(g, trailhead)
 	}
 
 	return totalScore
 }
 
-func findTrailheads(grid [][]int) []Position {
-	trailheads := []Position{}
+func findTrailheads(g *grid.Grid[int]) []grid.Point {
+	trailheads := []grid.Point{}
 
-	for r := 0; r < len(grid); r++ {
-		for c := 0; c < len(grid[r]); c++ {
-			// Check if the current position is a trailhead (height 0)
-			if grid[r][c] == 0 {
-				trailheads = append(trailheads, Position{r, c})
-			}
+	for p, height := range g.All() {
+		// Check if the current position is a trailhead (height 0)
+		if height == 0 {
+			trailheads = append(trailheads, p)
 		}
 	}
 
 	return trailheads
 }
 
-func calculateTrailheadScore(grid [][]int, start Position) int {
-	rows, cols := len(grid), len(grid[0])
-	visited9Positions := make(map[Position]bool)
+func calculateTrailheadScore(g *grid.Grid[int], start grid.Point) int {
+	visited9Positions := make(map[grid.Point]bool)
 
 	// Depth-first search to find all reachable 9-height positions
-	var dfs func(r, c, prevHeight int)
-	dfs = func(r, c, prevHeight int) {
+	var dfs func(p grid.Point, prevHeight int)
+	dfs = func(p grid.Point, prevHeight int) {
 		// Check bounds and validity of movement
-		if r < 0 || r >= rows || c < 0 || c >= cols {
+		currentHeight, ok := g.Get(p)
+		if !ok {
 			return
 		}
 
 		// Check if current cell is impassable (represented by '.')
-		if grid[r][c] == '.' {
+		if currentHeight == impassable {
 			return
 		}
 
-		currentHeight := grid[r][c]
-
 		// Check if height change is valid (exactly 1)
 		if prevHeight != -1 && currentHeight != prevHeight+1 {
 			return
@@ -90,39 +60,38 @@ func calculateTrailheadScore(grid [][]int, start Position) int {
 
 		// Mark 9-height positions
 		if currentHeight == 9 {
-			visited9Positions[Position{r, c}] = true
+			visited9Positions[p] = true
 		}
 
 		// Try all 4 directions (up, down, left, right)
-		dfs(r+1, c, currentHeight)
-		dfs(r-1, c, currentHeight)
-		dfs(r, c+1, currentHeight)
-		dfs(r, c-1, currentHeight)
+		for _, next := range p.Neighbours4() {
+			dfs(next, currentHeight)
+		}
 	}
 
 	// Start DFS from the trailhead with initial height -1
-	dfs(start.row, start.col, -1)
+	dfs(start, -1)
 
 	return len(visited9Positions)
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+// Solver solves part one of day 10 using the topographic map
+type Solver struct {
+	grid *grid.Grid[int]
+}
 
-	// Parse the file
-	input, err := parseFile(file)
+// Parse reads the height of every position on the map
+func (s *Solver) Parse(r io.Reader) error {
+	g, err := grid.Parse(r, grid.AsDigit)
 	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
+		return err
 	}
 
-	// Calculate the total score
-	totalScore := CalculateTrailheadScores(input)
-	fmt.Println("Total score:", totalScore)
+	s.grid = g
+	return nil
+}
+
+// Solve returns the sum of the scores of all trailheads
+func (s *Solver) Solve() (aoc.Answer, error) {
+	return aoc.Int(CalculateTrailheadScores(s.grid)), nil
 }
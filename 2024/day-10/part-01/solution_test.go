@@ -1,7 +1,9 @@
-package main
+package part01
 
 import (
 	"testing"
+
+	"aoc2024/grid"
 )
 
 func TestCalculateTrailheadScores(t *testing.T) {
@@ -77,7 +79,11 @@ func TestCalculateTrailheadScores(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := CalculateTrailheadScores(test.grid)
+			g, err := grid.FromRows(test.grid)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			result := CalculateTrailheadScores(g)
 			if result != test.expected {
 				t.Errorf("CalculateTrailheadScores() = %d; want %d", result, test.expected)
 			}
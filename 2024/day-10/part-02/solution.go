@@ -1,94 +1,65 @@
-package main
+package part02
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"os"
-	"strconv"
-)
-
-type Position struct {
-	row, col int
-}
-
-func parseFile(r io.Reader) ([][]int, error) {
-	var result [][]int
-	scanner := bufio.NewScanner(r)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		var row []int
-		for _, char := range line {
-			num, err := strconv.Atoi(string(char))
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse character %c: %v", char, err)
-			}
-			row = append(row, num)
-		}
-		result = append(result, row)
-	}
+	"maps"
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
+	"aoc2024/aoc"
+	"aoc2024/grid"
+)
 
-	return result, nil
-}
+// impassable marks tiles of hand-drawn maps that cannot be walked on
+const impassable = '.'
 
-func CalculateTrailheadScores(grid [][]int) int {
+func CalculateTrailheadScores(g *grid.Grid[int]) int {
 	// Find all trailheads (positions with height 0)
-	trailheads := findTrailheads(grid)
+	trailheads := findTrailheads(g)
 
 	// Calculate scores for each trailhead
 	totalScore := 0
 	for _, trailhead := range trailheads {
-		totalScore += calculateTrailheadScore(grid, trailhead)
+		totalScore += calculateTrailheadScore(g, trailhead)
 	}
 
 	return totalScore
 }
 
-func findTrailheads(grid [][]int) []Position {
-	trailheads := []Position{}
+func findTrailheads(g *grid.Grid[int]) []grid.Point {
+	trailheads := []grid.Point{}
 
-	for r := 0; r < len(grid); r++ {
-		for c := 0; c < len(grid[r]); c++ {
-			// Check if the current position is a trailhead (height 0)
-			if grid[r][c] == 0 {
-				trailheads = append(trailheads, Position{r, c})
-			}
+	for p, height := range g.All() {
+		// Check if the current position is a trailhead (height 0)
+		if height == 0 {
+			trailheads = append(trailheads, p)
 		}
 	}
 
 	return trailheads
 }
 
-func calculateTrailheadScore(grid [][]int, start Position) int {
-	rows, cols := len(grid), len(grid[0])
+func calculateTrailheadScore(g *grid.Grid[int], start grid.Point) int {
 	trailCount := 0
 
 	// Function to check if a trail is valid
-	isValidTrail := func(trail []Position) bool {
+	isValidTrail := func(trail []grid.Point) bool {
 		if len(trail) == 0 {
 			return false
 		}
 
 		// Must start at trailhead (height 0)
-		if grid[trail[0].row][trail[0].col] != 0 {
+		if g.At(trail[0]) != 0 {
 			return false
 		}
 
 		// Must end at height 9
-		lastPos := trail[len(trail)-1]
-		if grid[lastPos.row][lastPos.col] != 9 {
+		if g.At(trail[len(trail)-1]) != 9 {
 			return false
 		}
 
 		// Check height increases exactly by 1 at each step
 		for i := 1; i < len(trail); i++ {
-			prevHeight := grid[trail[i-1].row][trail[i-1].col]
-			currHeight := grid[trail[i].row][trail[i].col]
+			prevHeight := g.At(trail[i-1])
+			currHeight := g.At(trail[i])
 
 			// Ensure height increases by exactly 1
 			if currHeight != prevHeight+1 {
@@ -96,9 +67,7 @@ func calculateTrailheadScore(grid [][]int, start Position) int {
 			}
 
 			// Ensure move is orthogonal (not diagonal)
-			rowDiff := trail[i].row - trail[i-1].row
-			colDiff := trail[i].col - trail[i-1].col
-			if (rowDiff != 0 && colDiff != 0) || (abs(rowDiff)+abs(colDiff) != 1) {
+			if trail[i].Manhattan(trail[i-1]) != 1 {
 				return false
 			}
 		}
@@ -107,16 +76,16 @@ func calculateTrailheadScore(grid [][]int, start Position) int {
 	}
 
 	// Recursive function to find all possible trails
-	var findTrails func(current Position, trail []Position, visited map[Position]bool)
-	findTrails = func(current Position, trail []Position, visited map[Position]bool) {
+	var findTrails func(current grid.Point, trail []grid.Point, visited map[grid.Point]bool)
+	findTrails = func(current grid.Point, trail []grid.Point, visited map[grid.Point]bool) {
 		// Check bounds
-		if current.row < 0 || current.row >= rows ||
-			current.col < 0 || current.col >= cols {
+		currentHeight, ok := g.Get(current)
+		if !ok {
 			return
 		}
 
 		// Check for impassable tile
-		if grid[current.row][current.col] == '.' {
+		if currentHeight == impassable {
 			return
 		}
 
@@ -127,7 +96,7 @@ func calculateTrailheadScore(grid [][]int, start Position) int {
 
 		// Add current position to trail
 		newTrail := append(trail, current)
-		newVisited := copyMap(visited)
+		newVisited := maps.Clone(visited)
 		newVisited[current] = true
 
 		// If trail is valid, increment trail count
@@ -136,68 +105,38 @@ func calculateTrailheadScore(grid [][]int, start Position) int {
 		}
 
 		// Try moving in 4 directions
-		directions := []Position{
-			{current.row + 1, current.col},
-			{current.row - 1, current.col},
-			{current.row, current.col + 1},
-			{current.row, current.col - 1},
-		}
-
-		for _, dir := range directions {
+		for next := range g.Neighbours4(current) {
 			// Ensure height increases by exactly 1
-			if dir.row >= 0 && dir.row < rows &&
-				dir.col >= 0 && dir.col < cols &&
-				grid[dir.row][dir.col] != '.' {
-				currentHeight := grid[current.row][current.col]
-				nextHeight := grid[dir.row][dir.col]
-
-				if nextHeight == currentHeight+1 {
-					findTrails(dir, newTrail, newVisited)
-				}
+			nextHeight := g.At(next)
+			if nextHeight != impassable && nextHeight == currentHeight+1 {
+				findTrails(next, newTrail, newVisited)
 			}
 		}
 	}
 
 	// Start from trailhead with no prior trail
-	findTrails(start, []Position{}, make(map[Position]bool))
+	findTrails(start, []grid.Point{}, make(map[grid.Point]bool))
 
 	return trailCount
 }
 
-// Helper function to create a deep copy of a map
-func copyMap(m map[Position]bool) map[Position]bool {
-	newMap := make(map[Position]bool)
-	for k, v := range m {
-		newMap[k] = v
-	}
-	return newMap
-}
-
-// Helper function for absolute value
-func abs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
+// Solver solves part two of day 10 using the topographic map
+type Solver struct {
+	grid *grid.Grid[int]
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
+// Parse reads the height of every position on the map
+func (s *Solver) Parse(r io.Reader) error {
+	g, err := grid.Parse(r, grid.AsDigit)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return err
 	}
-	defer file.Close()
 
-	// Parse the file
-	input, err := parseFile(file)
-	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
-	}
+	s.grid = g
+	return nil
+}
 
-	// Calculate the total score
-	totalScore := CalculateTrailheadScores(input)
-	fmt.Println("Total score:", totalScore)
+// Solve returns the sum of the ratings of all trailheads
+func (s *Solver) Solve() (aoc.Answer, error) {
+	return aoc.Int(CalculateTrailheadScores(s.grid)), nil
 }
@@ -1,7 +1,9 @@
-package main
+package part02
 
 import (
 	"testing"
+
+	"aoc2024/grid"
 )
 
 func TestCalculateTrailheadScores(t *testing.T) {
@@ -66,7 +68,11 @@ func TestCalculateTrailheadScores(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := CalculateTrailheadScores(tt.grid)
+			g, err := grid.FromRows(tt.grid)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			result := CalculateTrailheadScores(g)
 			if result != tt.expected {
 				t.Errorf("expected %d, got %d", tt.expected, result)
 			}
@@ -1,8 +1,12 @@
-package main
+package part01
 
 import (
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
+
+	"aoc2024/aoc"
 )
 
 // simulateBlinks simulates the evolution of stones over a given number of blinks.
@@ -39,10 +43,41 @@ func splitStone(stone int) []int {
 	return []int{left, right}
 }
 
-func main() {
-	input := []int{0, 7, 6618216, 26481, 885, 42, 202642, 8791}
-	blinks := 25
+// parseFile reads the space separated numbers engraved on the stones
+func parseFile(r io.Reader) ([]int, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+
+	var stones []int
+	for _, field := range strings.Fields(string(content)) {
+		stone, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stone %q: %w", field, err)
+		}
+		stones = append(stones, stone)
+	}
+	return stones, nil
+}
+
+// Solver solves part one of day 11 using the initial arrangement of stones
+type Solver struct {
+	stones []int
+}
+
+// Parse reads the initial arrangement of stones
+func (s *Solver) Parse(r io.Reader) error {
+	stones, err := parseFile(r)
+	if err != nil {
+		return err
+	}
+
+	s.stones = stones
+	return nil
+}
 
-	stones := simulateBlinks(input, blinks)
-	fmt.Println("Number of stones after", blinks, "blinks:", len(stones))
+// Solve returns the number of stones after 25 blinks
+func (s *Solver) Solve() (aoc.Answer, error) {
+	return aoc.Int(len(simulateBlinks(s.stones, 25))), nil
 }
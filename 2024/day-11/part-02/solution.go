@@ -1,9 +1,13 @@
-package main
+package part02
 
 import (
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 	"sync"
+
+	"aoc2024/aoc"
 )
 
 /*
@@ -114,12 +118,41 @@ func simulateBlinks(stones []int, blinks int) int {
 	return totalStones
 }
 
-func main() {
-	input := []int{0, 7, 6618216, 26481, 885, 42, 202642, 8791}
-	blink_runs := []int{25, 75}
+// parseFile reads the space separated numbers engraved on the stones
+func parseFile(r io.Reader) ([]int, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+
+	var stones []int
+	for _, field := range strings.Fields(string(content)) {
+		stone, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stone %q: %w", field, err)
+		}
+		stones = append(stones, stone)
+	}
+	return stones, nil
+}
+
+// Solver solves part two of day 11 using the initial arrangement of stones
+type Solver struct {
+	stones []int
+}
 
-	for _, blinks := range blink_runs {
-		stoneCount := simulateBlinks(input, blinks)
-		fmt.Println("Number of stones after", blinks, "blinks:", stoneCount)
+// Parse reads the initial arrangement of stones
+func (s *Solver) Parse(r io.Reader) error {
+	stones, err := parseFile(r)
+	if err != nil {
+		return err
 	}
+
+	s.stones = stones
+	return nil
+}
+
+// Solve returns the number of stones after 75 blinks
+func (s *Solver) Solve() (aoc.Answer, error) {
+	return aoc.Int(simulateBlinks(s.stones, 75)), nil
 }
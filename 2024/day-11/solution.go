@@ -0,0 +1,51 @@
+// Package day11 combines the two halves of day 11, which live in their own
+// packages under part-01 and part-02.
+package day11
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+
+	"aoc2024/aoc"
+	part01 "aoc2024/day-11/part-01"
+	part02 "aoc2024/day-11/part-02"
+)
+
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 11, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 11, input)
+}
+
+// Solver solves day 11 by delegating to the solver of each part
+type Solver struct {
+	partOne part01.Solver
+	partTwo part02.Solver
+}
+
+// Parse hands a copy of the input to each part
+func (s *Solver) Parse(r io.Reader) error {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading input: %w", err)
+	}
+
+	if err := s.partOne.Parse(bytes.NewReader(input)); err != nil {
+		return err
+	}
+	return s.partTwo.Parse(bytes.NewReader(input))
+}
+
+// Part1 returns the number of stones after 25 blinks
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return s.partOne.Solve()
+}
+
+// Part2 returns the number of stones after 75 blinks
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return s.partTwo.Solve()
+}
@@ -1,68 +1,44 @@
-package main
+package day12
 
 import (
-	"bufio"
-	"fmt"
+	_ "embed"
 	"io"
-	"os"
-)
-
-// Coord represents a 2D coordinate with x and y values
-type Coord struct {
-	x, y int
-}
-
-// Grid represents the 2D grid of input data
-type Grid map[Coord]uint8
-
-// parseFile reads the input file and creates a grid representation
-func parseFile(r io.Reader) (Grid, int, error) {
-	scanner := bufio.NewScanner(r)
-	grid := make(Grid)
-	height := 0
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		for x, char := range line {
-			grid[Coord{height, x}] = uint8(char)
-		}
-		height++
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, -1, fmt.Errorf("error scanning file: %w", err)
-	}
 
-	return grid, height, nil
-}
+	"aoc2024/aoc"
+	"aoc2024/grid"
+)
 
 // exploreRegion explores a connected region in the grid
 type regionExplorer struct {
-	grid     Grid
-	visited  map[Coord]bool
-	regionID uint8
+	grid     *grid.Grid[byte]
+	visited  map[grid.Point]bool
+	regionID byte
 }
 
 // explore calculates area, perimeter, and corners of a region
 // Got the idea of exploring corners from:
 // https://www.reddit.com/r/adventofcode/comments/1hcf16m/2024_day_12_everyone_must_be_hating_today_so_here
-func (re *regionExplorer) explore(start Coord) (area, perimeter, corners int) {
-	if re.visited[start] {
+func (re *regionExplorer) explore(start grid.Point) (area, perimeter, corners int) {
+	if re.visited[start] || !re.grid.InBounds(start) {
 		return 0, 0, 0
 	}
 
-	re.regionID = re.grid[start]
+	re.regionID = re.grid.At(start)
 	re.visited[start] = true
 
-	var dfs func(Coord)
-	dfs = func(current Coord) {
+	// plant returns the plant type at p, or zero outside the map
+	plant := func(p grid.Point) byte {
+		val, _ := re.grid.Get(p)
+		return val
+	}
+
+	var dfs func(grid.Point)
+	dfs = func(current grid.Point) {
 		area++
 
 		// Cardinal directions
-		cardinalOffsets := []Coord{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
-		for _, offset := range cardinalOffsets {
-			next := Coord{current.x + offset.x, current.y + offset.y}
-			val, exists := re.grid[next]
+		for _, next := range current.Neighbours4() {
+			val, exists := re.grid.Get(next)
 			if exists && val == re.regionID && !re.visited[next] {
 				re.visited[next] = true
 				dfs(next)
@@ -72,18 +48,19 @@ func (re *regionExplorer) explore(start Coord) (area, perimeter, corners int) {
 		}
 
 		// Corner exploration
-		cornerOffsets := []Coord{{-1, -1}, {1, 1}, {1, -1}, {-1, 1}}
+		cornerOffsets := []grid.Point{{X: -1, Y: -1}, {X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}}
 		for _, corner := range cornerOffsets {
+			horizontal := plant(grid.Point{X: current.X + corner.X, Y: current.Y})
+			vertical := plant(grid.Point{X: current.X, Y: current.Y + corner.Y})
+
 			// Convex corner check
-			if re.grid[Coord{current.x + corner.x, current.y}] != re.regionID &&
-				re.grid[Coord{current.x, current.y + corner.y}] != re.regionID {
+			if horizontal != re.regionID && vertical != re.regionID {
 				corners++
 			}
 
 			// Concave corner check
-			if re.grid[Coord{current.x + corner.x, current.y}] == re.regionID &&
-				re.grid[Coord{current.x, current.y + corner.y}] == re.regionID &&
-				re.grid[Coord{current.x + corner.x, current.y + corner.y}] != re.regionID {
+			if horizontal == re.regionID && vertical == re.regionID &&
+				plant(current.Add(corner)) != re.regionID {
 				corners++
 			}
 		}
@@ -93,44 +70,56 @@ func (re *regionExplorer) explore(start Coord) (area, perimeter, corners int) {
 	return area, perimeter, corners
 }
 
-func calculatePrice(grid Grid, height int) (price, discountedPrice int) {
+func calculatePrice(g *grid.Grid[byte]) (price, discountedPrice int) {
 	explorer := &regionExplorer{
-		grid:    grid,
-		visited: make(map[Coord]bool),
+		grid:    g,
+		visited: make(map[grid.Point]bool),
 	}
 
-	for i := 0; i < height; i++ {
-		for j := 0; j < height; j++ {
-			current := Coord{i, j}
-			if explorer.visited[current] {
-				continue
-			}
-
-			area, perimeter, corners := explorer.explore(current)
-			price += area * perimeter
-			discountedPrice += area * corners
+	for current := range g.Points() {
+		if explorer.visited[current] {
+			continue
 		}
+
+		area, perimeter, corners := explorer.explore(current)
+		price += area * perimeter
+		discountedPrice += area * corners
 	}
 	return price, discountedPrice
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		os.Exit(1)
-	}
-	defer file.Close()
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 12, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 12, input)
+}
+
+// Solver solves day 12 using the garden plot map
+type Solver struct {
+	grid *grid.Grid[byte]
+}
 
-	// Parse the file
-	grid, height, err := parseFile(file)
+// Parse reads the garden plot map
+func (s *Solver) Parse(r io.Reader) error {
+	g, err := grid.Parse(r, grid.AsByte)
 	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		os.Exit(1)
+		return err
 	}
 
-	price, discountedPrice := calculatePrice(grid, height)
-	fmt.Println("Price:", price)
-	fmt.Println("Discounted Price:", discountedPrice)
+	s.grid = g
+	return nil
+}
+
+// Part1 returns the fencing price using area times perimeter
+func (s *Solver) Part1() (aoc.Answer, error) {
+	price, _ := calculatePrice(s.grid)
+	return aoc.Int(price), nil
+}
+
+// Part2 returns the discounted fencing price using area times sides
+func (s *Solver) Part2() (aoc.Answer, error) {
+	_, discountedPrice := calculatePrice(s.grid)
+	return aoc.Int(discountedPrice), nil
 }
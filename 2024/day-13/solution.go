@@ -1,11 +1,13 @@
-package main
+package day13
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
-	"os"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 type Pair struct{ x, y int }
@@ -104,36 +106,49 @@ func solve(clawMachine ClawMachine) int {
 	return 0
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		os.Exit(1)
-	}
-	defer file.Close()
+// The prizes of part two are this much further away on both axes
+const prizeOffset = 10000000000000
+
+//go:embed input.txt
+var input string
 
-	// Parse the file
-	clawMachines, err := parseFile(file)
+func init() {
+	aoc.Register(2024, 13, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 13, input)
+}
+
+// Solver solves day 13 using the claw machine configurations
+type Solver struct {
+	clawMachines []ClawMachine
+}
+
+// Parse reads the button and prize configuration of every machine
+func (s *Solver) Parse(r io.Reader) error {
+	clawMachines, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		os.Exit(1)
+		return err
 	}
 
-	// Part one
+	s.clawMachines = clawMachines
+	return nil
+}
+
+// Part1 returns the fewest tokens needed to win every winnable prize
+func (s *Solver) Part1() (aoc.Answer, error) {
 	tokens := 0
-	for _, clawMachine := range clawMachines {
+	for _, clawMachine := range s.clawMachines {
 		tokens += solve(clawMachine)
 	}
-	fmt.Println("The number of tokens required to win all prizes for part one is:", tokens)
-
-	// Part two
-	// Iterate over `clawMachine` and add 10000000000000 to `Prize.x` and `Prize.y`
-	tokens = 0
-	for _, clawMachine := range clawMachines {
-		clawMachine.Prize.x += 10000000000000
-		clawMachine.Prize.y += 10000000000000
+	return aoc.Int(tokens), nil
+}
+
+// Part2 returns the fewest tokens needed once the prizes have been moved
+func (s *Solver) Part2() (aoc.Answer, error) {
+	tokens := 0
+	for _, clawMachine := range s.clawMachines {
+		clawMachine.Prize.x += prizeOffset
+		clawMachine.Prize.y += prizeOffset
 		tokens += solve(clawMachine)
 	}
-	fmt.Println("The number of tokens required to win all prizes for part two is:", tokens)
+	return aoc.Int(tokens), nil
 }
@@ -0,0 +1,11 @@
+package day14
+
+import (
+	"testing"
+
+	"aoc2024/aoc/aoctest"
+)
+
+func BenchmarkParse(b *testing.B) { aoctest.BenchmarkParse(b, 2024, 14) }
+func BenchmarkPart1(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 14, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 14, 2) }
@@ -1,21 +1,20 @@
-package main
+package day14
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
-)
 
-type Point struct {
-	x, y int
-}
+	"aoc2024/aoc"
+	"aoc2024/grid"
+)
 
 type Robot struct {
-	position Point
-	velocity Point
+	position grid.Point
+	velocity grid.Point
 }
 
 func parseFile(r io.Reader) ([]Robot, error) {
@@ -61,45 +60,44 @@ func parseInput(line string) (Robot, error) {
 }
 
 // parseCoordinates parses a coordinate string like "0,4" into a Point
-func parseCoordinates(coord string) (Point, error) {
+func parseCoordinates(coord string) (grid.Point, error) {
 	coords := strings.Split(coord, ",")
 	if len(coords) != 2 {
-		return Point{}, fmt.Errorf("invalid coordinate format: %s", coord)
+		return grid.Point{}, fmt.Errorf("invalid coordinate format: %s", coord)
 	}
 
 	x, err := strconv.Atoi(coords[0])
 	if err != nil {
-		return Point{}, err
+		return grid.Point{}, err
 	}
 
 	y, err := strconv.Atoi(coords[1])
 	if err != nil {
-		return Point{}, err
+		return grid.Point{}, err
 	}
 
-	return Point{x: x, y: y}, nil
+	return grid.Point{X: x, Y: y}, nil
 }
 
 // calculatePosition determines the final position after n seconds
-func (r Robot) calculatePosition(n, width, height int) Point {
+func (r Robot) calculatePosition(n, width, height int) grid.Point {
 	// Calculate new position using modular arithmetic for wrap-around
-	newX := ((r.position.x+r.velocity.x*n)%width + width) % width
-	newY := ((r.position.y+r.velocity.y*n)%height + height) % height
-	return Point{x: newX, y: newY}
+	p := r.position.Add(r.velocity.Scale(n))
+	return grid.Point{X: (p.X%width + width) % width, Y: (p.Y%height + height) % height}
 }
 
-func getQuadrant(p Point, width, height int) int {
+func getQuadrant(p grid.Point, width, height int) int {
 	midX := width / 2
 	midY := height / 2
 
 	switch {
-	case p.x == midX || p.y == midY:
+	case p.X == midX || p.Y == midY:
 		return 0 // On the Midline
-	case p.x < midX && p.y < midY:
+	case p.X < midX && p.Y < midY:
 		return 1 // Top-left
-	case p.x < midX && p.y >= midY:
+	case p.X < midX && p.Y >= midY:
 		return 3 // Bottom-left
-	case p.x >= midX && p.y < midY:
+	case p.X >= midX && p.Y < midY:
 		return 2 // Top-right
 	default:
 		return 4 // Bottom-right
@@ -153,19 +151,19 @@ func findMaxConsecutiveRobots(robots []Robot, width, height, seconds int) (int,
 	return maxConsecutive, maxTime
 }
 
-func trackRobotPositions(robots []Robot, time, width, height int) map[Point]bool {
-	positions := make(map[Point]bool)
+func trackRobotPositions(robots []Robot, time, width, height int) *grid.Grid[bool] {
+	positions := grid.New[bool](width, height)
 	for _, robot := range robots {
 		pos := robot.calculatePosition(time, width, height)
-		positions[pos] = true
+		positions.Set(pos, true)
 	}
 	return positions
 }
 
-func countConsecutiveRobots(positions map[Point]bool, startX, y, width int) int {
+func countConsecutiveRobots(positions *grid.Grid[bool], startX, y, width int) int {
 	consecutive := 0
 	for x := startX; x < width; x++ {
-		if !positions[Point{x: x, y: y}] {
+		if !positions.At(grid.Point{X: x, Y: y}) {
 			break
 		}
 		consecutive++
@@ -173,33 +171,46 @@ func countConsecutiveRobots(positions map[Point]bool, startX, y, width int) int
 	return consecutive
 }
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+// Dimensions of the bathroom and the time spans of each part
+const (
+	roomWidth     = 101
+	roomHeight    = 103
+	safetySeconds = 100
+	searchSeconds = 10000
+)
+
+//go:embed input.txt
+var input string
 
-	robots, err := parseFile(file)
+func init() {
+	aoc.Register(2024, 14, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 14, input)
+}
+
+// Solver solves day 14 using the robots' positions and velocities
+type Solver struct {
+	robots []Robot
+}
+
+// Parse reads one robot per line
+func (s *Solver) Parse(r io.Reader) error {
+	robots, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
+		return err
 	}
 
-	width := 101
-	height := 103
-	seconds := 100
+	s.robots = robots
+	return nil
+}
 
-	// Calculate the safety factor
-	safetyFactor := calculateSafetyFactor(robots, width, height, seconds)
-	fmt.Println("Part one:")
-	fmt.Println("\tSafety factor:", safetyFactor)
+// Part1 returns the safety factor after 100 seconds
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(calculateSafetyFactor(s.robots, roomWidth, roomHeight, safetySeconds)), nil
+}
 
-	// Find the maximum consecutive robots on a line
-	seconds = 10000
-	maxConsecutive, maxTime := findMaxConsecutiveRobots(robots, width, height, seconds)
-	fmt.Println("Part two:")
-	fmt.Println("\tMaximum consecutive robots: ", maxConsecutive)
-	fmt.Println("\tFound at time: ", maxTime)
+// Part2 returns the first second at which the robots line up the longest,
+// which is when they draw the Christmas tree
+func (s *Solver) Part2() (aoc.Answer, error) {
+	_, maxTime := findMaxConsecutiveRobots(s.robots, roomWidth, roomHeight, searchSeconds)
+	return aoc.Int(maxTime), nil
 }
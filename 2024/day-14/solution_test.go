@@ -1,7 +1,9 @@
-package main
+package day14
 
 import (
 	"testing"
+
+	"aoc2024/grid"
 )
 
 func TestParseInput(t *testing.T) {
@@ -13,8 +15,8 @@ func TestParseInput(t *testing.T) {
 		{
 			input: "p=0,4 v=3,-3",
 			expected: Robot{
-				position: Point{x: 0, y: 4},
-				velocity: Point{x: 3, y: -3},
+				position: grid.Point{X: 0, Y: 4},
+				velocity: grid.Point{X: 3, Y: -3},
 			},
 			wantErr: false,
 		},
@@ -40,17 +42,17 @@ func TestParseInput(t *testing.T) {
 func TestCalculatePosition(t *testing.T) {
 	// Test case from the problem example
 	robot := Robot{
-		position: Point{x: 2, y: 4},
-		velocity: Point{x: 2, y: -3},
+		position: grid.Point{X: 2, Y: 4},
+		velocity: grid.Point{X: 2, Y: -3},
 	}
 
-	expectedPositions := []Point{
-		{x: 2, y: 4},  // Initial
-		{x: 4, y: 1},  // After 1 second
-		{x: 6, y: 5},  // After 2 seconds
-		{x: 8, y: 2},  // After 3 seconds
-		{x: 10, y: 6}, // After 4 seconds
-		{x: 1, y: 3},  // After 5 seconds
+	expectedPositions := []grid.Point{
+		{X: 2, Y: 4},  // Initial
+		{X: 4, Y: 1},  // After 1 second
+		{X: 6, Y: 5},  // After 2 seconds
+		{X: 8, Y: 2},  // After 3 seconds
+		{X: 10, Y: 6}, // After 4 seconds
+		{X: 1, Y: 3},  // After 5 seconds
 	}
 
 	width, height := 11, 7
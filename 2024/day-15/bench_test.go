@@ -0,0 +1,11 @@
+package day15
+
+import (
+	"testing"
+
+	"aoc2024/aoc/aoctest"
+)
+
+func BenchmarkParse(b *testing.B) { aoctest.BenchmarkParse(b, 2024, 15) }
+func BenchmarkPart1(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 15, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 15, 2) }
@@ -1,11 +1,12 @@
-package main
+package part01
 
 import (
 	"bufio"
 	"errors"
-	"fmt"
 	"io"
-	"os"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
 const (
@@ -15,43 +16,18 @@ const (
 	robot = '@'
 )
 
-type Point struct {
-	row, col int
-}
-
-type Direction struct {
-	dRow, dCol int
-}
-
-var directions = map[byte]Direction{
-	'<': {0, -1},
-	'>': {0, 1},
-	'^': {-1, 0},
-	'v': {1, 0},
-}
-
-func parseFile(r io.Reader) (map[Point]byte, []byte, Point, error) {
-	var robotPos Point
-	grid := make(map[Point]byte)
+func parseFile(r io.Reader) (*grid.Grid[byte], []byte, grid.Point, error) {
+	var lines []string
 
 	scanner := bufio.NewScanner(r)
 
 	// Parse grid
-	for row := 0; scanner.Scan(); row++ {
+	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			break
 		}
-
-		for col, char := range line {
-			pos := Point{row, col}
-			if char == robot {
-				robotPos = pos
-				grid[pos] = empty
-			} else {
-				grid[pos] = byte(char)
-			}
-		}
+		lines = append(lines, line)
 	}
 
 	var moves []byte
@@ -60,83 +36,105 @@ func parseFile(r io.Reader) (map[Point]byte, []byte, Point, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, nil, Point{}, err
+		return nil, nil, grid.Point{}, err
 	}
 
-	return grid, moves, robotPos, nil
+	warehouse, err := grid.FromLines(lines, grid.AsByte)
+	if err != nil {
+		return nil, nil, grid.Point{}, err
+	}
+
+	robotPos, found := warehouse.IndexFunc(func(char byte) bool { return char == robot })
+	if !found {
+		return nil, nil, grid.Point{}, errors.New("no robot in the warehouse")
+	}
+	warehouse.Set(robotPos, empty)
+
+	return warehouse, moves, robotPos, nil
 }
 
 // findNextEmpty finds the next empty cell in the given direction
-func findNextEmpty(grid map[Point]byte, pos Point, dir Direction) (Point, error) {
+func findNextEmpty(warehouse *grid.Grid[byte], pos grid.Point, dir grid.Direction) (grid.Point, error) {
 	for {
-		pos = Point{pos.row + dir.dRow, pos.col + dir.dCol}
-		switch grid[pos] {
-		case empty:
+		pos = pos.Move(dir)
+		char, ok := warehouse.Get(pos)
+		switch {
+		case !ok || char == wall:
+			return grid.Point{}, errors.New("wall encountered")
+		case char == empty:
 			return pos, nil
-		case wall:
-			return Point{}, errors.New("wall encountered")
 		}
 	}
 }
 
 // calculateScore computes the score based on box positions
-func calculateScore(grid map[Point]byte) int {
+func calculateScore(warehouse *grid.Grid[byte]) int {
 	var score int
-	for pos, char := range grid {
+	for pos, char := range warehouse.All() {
 		if char == box {
-			score += 100*pos.row + pos.col
+			score += 100*pos.Y + pos.X
 		}
 	}
 	return score
 }
 
 // Attempts to move the robot in the given direction
-func Solve(grid map[Point]byte, move byte, robotPos Point) Point {
-	dir, exists := directions[move]
+func Solve(warehouse *grid.Grid[byte], move byte, robotPos grid.Point) grid.Point {
+	dir, exists := grid.DirectionFromArrow(move)
 	if !exists {
 		return robotPos
 	}
 
-	nextPos := Point{robotPos.row + dir.dRow, robotPos.col + dir.dCol}
+	nextPos := robotPos.Move(dir)
+	next, _ := warehouse.Get(nextPos)
 
 	// Check if next position contains a box
-	if grid[nextPos] == box {
-		if emptyPos, err := findNextEmpty(grid, robotPos, dir); err == nil {
+	if next == box {
+		if emptyPos, err := findNextEmpty(warehouse, robotPos, dir); err == nil {
 			// Move the box
-			grid[nextPos] = empty
-			grid[emptyPos] = box
+			warehouse.Set(nextPos, empty)
+			warehouse.Set(emptyPos, box)
 			return nextPos
 		}
 		return robotPos
 	}
 
 	// Move robot if next position is empty
-	if grid[nextPos] == empty {
+	if next == empty {
 		return nextPos
 	}
 
 	return robotPos
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
+// Solver solves part one of day 15 using the warehouse map and moves
+type Solver struct {
+	grid     *grid.Grid[byte]
+	moves    []byte
+	robotPos grid.Point
+}
 
-	// Parse the file
-	grid, moves, robotPos, err := parseFile(file)
+// Parse reads the warehouse map followed by the robot's moves
+func (s *Solver) Parse(r io.Reader) error {
+	warehouse, moves, robotPos, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
+		return err
 	}
 
-	for _, move := range moves {
-		robotPos = Solve(grid, move, robotPos)
+	s.grid = warehouse
+	s.moves = moves
+	s.robotPos = robotPos
+	return nil
+}
+
+// Solve returns the sum of the boxes' GPS coordinates after all moves
+func (s *Solver) Solve() (aoc.Answer, error) {
+	// Work on a copy so the parsed warehouse can be solved again
+	warehouse := s.grid.Clone()
+	robotPos := s.robotPos
+	for _, move := range s.moves {
+		robotPos = Solve(warehouse, move, robotPos)
 	}
 
-	fmt.Println(calculateScore(grid))
+	return aoc.Int(calculateScore(warehouse)), nil
 }
@@ -1,33 +1,22 @@
-package main
+package part02
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
-	"os"
+	"strings"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
 // GameState represents the current state of the puzzle
 type GameState struct {
-	Grid    Grid
-	Robot   Position
-	MaxRows int
-	MaxCols int
-}
-
-// Position represents a point on the grid
-type Position struct {
-	Row, Col int
-}
-
-// Direction represents a movement vector
-type Direction struct {
-	DRow, DCol int
+	Grid  *grid.Grid[rune]
+	Robot grid.Point
 }
 
-// Grid is a wrapper around the map representation of the game board
-type Grid map[Position]rune
-
 // Constants for game elements
 const (
 	Empty = '.'
@@ -37,58 +26,31 @@ const (
 	Robot = '@'
 )
 
-// Movement directions
-var directions = map[byte]Direction{
-	'<': {0, -1},
-	'>': {0, 1},
-	'^': {-1, 0},
-	'v': {1, 0},
-}
-
 // NewGameState creates and initializes a new game state
 func NewGameState(r io.Reader) (*GameState, []byte, error) {
-	grid, moves, robotPos, err := parseInput(r)
+	warehouse, moves, robotPos, err := parseInput(r)
 	if err != nil {
 		return nil, nil, fmt.Errorf("parsing input: %w", err)
 	}
 
-	maxRows, maxCols := calculateGridDimensions(grid)
-
 	return &GameState{
-		Grid:    grid,
-		Robot:   robotPos,
-		MaxRows: maxRows,
-		MaxCols: maxCols,
+		Grid:  warehouse,
+		Robot: robotPos,
 	}, moves, nil
 }
 
 // parseInput reads the puzzle input and returns the initial game state
-func parseInput(r io.Reader) (Grid, []byte, Position, error) {
+func parseInput(r io.Reader) (*grid.Grid[rune], []byte, grid.Point, error) {
 	scanner := bufio.NewScanner(r)
-	tempGrid := make(Grid)
-	var robotPos Position
-	var maxCol int
+	var lines []string
 
 	// Parse the grid
-	for row := 0; scanner.Scan(); row++ {
+	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			break
 		}
-
-		if len(line) > maxCol {
-			maxCol = len(line)
-		}
-
-		for col, char := range line {
-			pos := Position{row, col}
-			if char == Robot {
-				robotPos = pos
-				tempGrid[pos] = Empty
-			} else {
-				tempGrid[pos] = char
-			}
-		}
+		lines = append(lines, line)
 	}
 
 	// Parse the moves
@@ -98,32 +60,32 @@ func parseInput(r io.Reader) (Grid, []byte, Position, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, nil, Position{}, fmt.Errorf("scanning input: %w", err)
+		return nil, nil, grid.Point{}, fmt.Errorf("scanning input: %w", err)
+	}
+
+	tempGrid, err := grid.FromLines(lines, grid.AsRune)
+	if err != nil {
+		return nil, nil, grid.Point{}, fmt.Errorf("parsing warehouse: %w", err)
+	}
+
+	robotPos, found := tempGrid.IndexFunc(func(char rune) bool { return char == Robot })
+	if !found {
+		return nil, nil, grid.Point{}, errors.New("no robot in the warehouse")
 	}
 
 	expandedGrid := expandGrid(tempGrid)
-	robotPos.Col *= 2 // Adjust for expanded grid
+	robotPos.X *= 2 // Adjust for expanded grid
 
 	return expandedGrid, moves, robotPos, nil
 }
 
 // expandGrid doubles the grid horizontally to handle box movements
-func expandGrid(input Grid) Grid {
-	expanded := make(Grid)
-	maxRow, maxCol := calculateGridDimensions(input)
-
-	for row := 0; row <= maxRow; row++ {
-		for col := 0; col <= maxCol*2+1; col++ {
-			pos := Position{row, col}
-			originalCol := col / 2
-			originalPos := Position{row, originalCol}
-
-			if char, exists := input[originalPos]; exists {
-				expanded[pos] = expandCharacter(char, col%2 == 0)
-			} else {
-				expanded[pos] = Empty
-			}
-		}
+func expandGrid(input *grid.Grid[rune]) *grid.Grid[rune] {
+	expanded := grid.New[rune](input.Width()*2, input.Height())
+
+	for pos := range expanded.Points() {
+		originalPos := grid.Point{X: pos.X / 2, Y: pos.Y}
+		expanded.Set(pos, expandCharacter(input.At(originalPos), pos.X%2 == 0))
 	}
 	return expanded
 }
@@ -143,46 +105,29 @@ func expandCharacter(char rune, isEven bool) rune {
 	}
 }
 
-// calculateGridDimensions returns the maximum row and column values in the grid
-func calculateGridDimensions(grid Grid) (maxRow, maxCol int) {
-	for pos := range grid {
-		if pos.Row > maxRow {
-			maxRow = pos.Row
-		}
-		if pos.Col > maxCol {
-			maxCol = pos.Col
+// String draws the current state of the game one row per line
+func (g *GameState) String() string {
+	var sb strings.Builder
+	for pos, char := range g.Grid.All() {
+		if pos == g.Robot {
+			char = Robot
 		}
-	}
-	return
-}
-
-// Print displays the current state of the game
-func (g *GameState) Print() {
-	for row := 0; row <= g.MaxRows; row++ {
-		for col := 0; col <= g.MaxCols; col++ {
-			pos := Position{row, col}
-			if pos == g.Robot {
-				fmt.Print(string(Robot))
-			} else {
-				char, exists := g.Grid[pos]
-				if !exists {
-					char = Empty
-				}
-				fmt.Print(string(char))
-			}
+		sb.WriteRune(char)
+		if pos.X == g.Grid.Width()-1 {
+			sb.WriteByte('\n')
 		}
-		fmt.Println()
 	}
+	return sb.String()
 }
 
 // Move attempts to move the robot in the specified direction
 func (g *GameState) Move(move byte) {
-	dir, exists := directions[move]
+	dir, exists := grid.DirectionFromArrow(move)
 	if !exists {
 		return
 	}
 
-	if move == '<' || move == '>' {
+	if dir == grid.West || dir == grid.East {
 		g.handleHorizontalMove(dir)
 	} else {
 		g.handleVerticalMove(dir)
@@ -190,53 +135,61 @@ func (g *GameState) Move(move byte) {
 }
 
 // handleHorizontalMove processes horizontal robot movements
-func (g *GameState) handleHorizontalMove(dir Direction) {
+func (g *GameState) handleHorizontalMove(dir grid.Direction) {
 	nextEmpty, ok := g.findNextEmpty(dir)
 	if !ok {
 		return
 	}
 
 	// Move boxes
+	back := dir.Reverse()
 	for curr := nextEmpty; curr != g.Robot; {
-		prev := Position{curr.Row, curr.Col - dir.DCol}
-		g.Grid[curr], g.Grid[prev] = g.Grid[prev], g.Grid[curr]
+		prev := curr.Move(back)
+		g.swap(curr, prev)
 		curr = prev
 	}
 
 	// Move robot
-	g.Robot.Col += dir.DCol
+	g.Robot = g.Robot.Move(dir)
 }
 
 // handleVerticalMove processes vertical robot movements
-func (g *GameState) handleVerticalMove(dir Direction) {
-	affected, maxLevel, ok := g.findAffectedColumns(dir.DRow)
+func (g *GameState) handleVerticalMove(dir grid.Direction) {
+	deltaRow := dir.Delta().Y
+	affected, maxLevel, ok := g.findAffectedColumns(deltaRow)
 	if !ok {
 		return
 	}
 
 	// Move boxes
-	for row := maxLevel; row != g.Robot.Row; row -= dir.DRow {
+	for row := maxLevel; row != g.Robot.Y; row -= deltaRow {
 		for col := range affected[row] {
-			pos1 := Position{row + dir.DRow, col}
-			pos2 := Position{row, col}
-			g.Grid[pos1], g.Grid[pos2] = g.Grid[pos2], g.Grid[pos1]
+			g.swap(grid.Point{X: col, Y: row + deltaRow}, grid.Point{X: col, Y: row})
 		}
 	}
 
 	// Move robot
-	g.Robot.Row += dir.DRow
+	g.Robot = g.Robot.Move(dir)
+}
+
+// swap exchanges the contents of two cells
+func (g *GameState) swap(a, b grid.Point) {
+	charA, charB := g.Grid.At(a), g.Grid.At(b)
+	g.Grid.Set(a, charB)
+	g.Grid.Set(b, charA)
 }
 
 // findNextEmpty finds the next empty position in the specified direction
-func (g *GameState) findNextEmpty(dir Direction) (Position, bool) {
+func (g *GameState) findNextEmpty(dir grid.Direction) (grid.Point, bool) {
 	pos := g.Robot
 	for {
-		pos = Position{pos.Row + dir.DRow, pos.Col + dir.DCol}
-		switch g.Grid[pos] {
-		case Empty:
+		pos = pos.Move(dir)
+		char, ok := g.Grid.Get(pos)
+		switch {
+		case !ok || char == Wall:
+			return grid.Point{}, false
+		case char == Empty:
 			return pos, true
-		case Wall:
-			return Position{}, false
 		}
 	}
 }
@@ -244,10 +197,10 @@ func (g *GameState) findNextEmpty(dir Direction) (Position, bool) {
 // findAffectedColumns determines which columns are affected by a vertical move
 func (g *GameState) findAffectedColumns(deltaRow int) (map[int]map[int]struct{}, int, bool) {
 	affected := map[int]map[int]struct{}{
-		g.Robot.Row: {g.Robot.Col: struct{}{}},
+		g.Robot.Y: {g.Robot.X: struct{}{}},
 	}
 
-	for currRow := g.Robot.Row; ; currRow += deltaRow {
+	for currRow := g.Robot.Y; ; currRow += deltaRow {
 		newCols, ok := g.findNewColumns(currRow+deltaRow, affected[currRow])
 		if !ok {
 			return nil, 0, false
@@ -265,13 +218,14 @@ func (g *GameState) findAffectedColumns(deltaRow int) (map[int]map[int]struct{},
 func (g *GameState) findNewColumns(nextRow int, columns map[int]struct{}) (map[int]struct{}, bool) {
 	newCols := make(map[int]struct{})
 	for col := range columns {
-		switch g.Grid[Position{nextRow, col}] {
-		case Wall:
+		char, ok := g.Grid.Get(grid.Point{X: col, Y: nextRow})
+		switch {
+		case !ok || char == Wall:
 			return nil, false
-		case BoxL:
+		case char == BoxL:
 			newCols[col] = struct{}{}
 			newCols[col+1] = struct{}{}
-		case BoxR:
+		case char == BoxR:
 			newCols[col] = struct{}{}
 			newCols[col-1] = struct{}{}
 		}
@@ -282,34 +236,40 @@ func (g *GameState) findNewColumns(nextRow int, columns map[int]struct{}) (map[i
 // CalculateScore computes the final score based on box positions
 func (g *GameState) CalculateScore() int {
 	score := 0
-	for pos, char := range g.Grid {
+	for pos, char := range g.Grid.All() {
 		if char == BoxL {
-			score += 100*pos.Row + pos.Col
+			score += 100*pos.Y + pos.X
 		}
 	}
 	return score
 }
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		os.Exit(1)
-	}
-	defer file.Close()
+// Solver solves part two of day 15 using the widened warehouse and moves
+type Solver struct {
+	game  *GameState
+	moves []byte
+}
 
-	game, moves, err := NewGameState(file)
+// Parse reads the warehouse map, widening it, followed by the robot's moves
+func (s *Solver) Parse(r io.Reader) error {
+	game, moves, err := NewGameState(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error initializing game: %v\n", err)
-		os.Exit(1)
+		return err
 	}
 
-	// Execute all moves
-	for _, move := range moves {
+	s.game = game
+	s.moves = moves
+	return nil
+}
+
+// Solve returns the sum of the boxes' GPS coordinates after all moves
+func (s *Solver) Solve() (aoc.Answer, error) {
+	// Work on a copy so the parsed warehouse can be solved again
+	game := *s.game
+	game.Grid = s.game.Grid.Clone()
+	for _, move := range s.moves {
 		game.Move(move)
 	}
 
-	// Print final state and score
-	game.Print()
-	fmt.Printf("Final score: %d\n", game.CalculateScore())
+	return aoc.Int(game.CalculateScore()), nil
 }
@@ -0,0 +1,53 @@
+package day16
+
+import (
+	"strings"
+	"testing"
+
+	"aoc2024/aoc/aoctest"
+	"aoc2024/grid"
+	"aoc2024/search"
+)
+
+func BenchmarkParse(b *testing.B) { aoctest.BenchmarkParse(b, 2024, 16) }
+func BenchmarkPart1(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 16, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 16, 2) }
+
+// realMaze parses the embedded puzzle input
+func realMaze(b *testing.B) *grid.Grid[byte] {
+	b.Helper()
+	maze, err := ParseMaze(strings.NewReader(input))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return maze
+}
+
+// BenchmarkBestTiles times finding the tiles on best paths with the dense
+// score table indexed by (y, x, direction)
+func BenchmarkBestTiles(b *testing.B) {
+	maze := realMaze(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindLowestScoreWithPaths(maze, DefaultRules)
+	}
+}
+
+// BenchmarkBestTilesMap does the same search keeping scores and predecessors
+// in maps keyed by State, for comparison with BenchmarkBestTiles
+func BenchmarkBestTilesMap(b *testing.B) {
+	maze := realMaze(b)
+	start, end := findStartAndEndPositions(maze)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dag, _ := search.AllShortestPaths(
+			State{pos: start, dir: DefaultRules.Facing},
+			getPossibleMoves(maze, DefaultRules.Costs),
+			func(s State) bool { return s.pos == end },
+		)
+		tiles := make(map[grid.Point]bool)
+		for s := range dag.States() {
+			tiles[s.pos] = true
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Command day16 solves Advent of Code 2024 day 16. It reads the file given
+// with -input, or standard input for "-input -", and otherwise uses the
+// puzzle input embedded in the binary. With -routes n it also lists the n
+// cheapest distinct routes through the maze on standard error, and with
+// -render file it draws the best paths as a PNG image or as coloured text
+// ("-" for standard error). The other flags change the scores of the
+// reindeer's moves and its starting heading.
+package main
+
+import (
+	"flag"
+	"os"
+
+	"aoc2024/aoc"
+	day16 "aoc2024/day-16"
+	"aoc2024/grid"
+)
+
+func main() {
+	costs := day16.DefaultRules.Costs
+	render := flag.String("render", "", "draw the best paths to this .png or text file, or - for standard error")
+	routes := flag.Int("routes", 0, "list this many of the cheapest routes on standard error")
+	facing := flag.String("facing", day16.DefaultRules.Facing.String(), "direction the reindeer faces at the start (N, E, S, W or an arrow)")
+	flag.IntVar(&costs.Forward, "forward", costs.Forward, "score of stepping forward")
+	flag.IntVar(&costs.Clockwise, "cw", costs.Clockwise, "score of turning clockwise")
+	flag.IntVar(&costs.CounterClockwise, "ccw", costs.CounterClockwise, "score of turning counter-clockwise")
+	flag.IntVar(&costs.UTurn, "uturn", costs.UTurn, "score of turning around in one move, 0 to disallow")
+	flag.BoolVar(&costs.TileWeights, "weights", costs.TileWeights, "multiply the forward score by the digit on weighted tiles")
+
+	aoc.MainWith(2024, 16, func() (aoc.Solver, error) {
+		dir, err := grid.ParseDirection(*facing)
+		if err != nil {
+			return nil, err
+		}
+
+		return day16.NewSolver(day16.Options{
+			Rules:      day16.Rules{Costs: costs, Facing: dir},
+			RenderPath: *render,
+			RouteCount: *routes,
+			RouteLog:   os.Stderr,
+		})
+	})
+}
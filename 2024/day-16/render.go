@@ -0,0 +1,157 @@
+package day16
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"aoc2024/grid"
+)
+
+// ANSI escape sequences used by RenderText
+const (
+	ansiReset  = "\x1b[0m"
+	ansiWall   = "\x1b[90m"   // Dark grey
+	ansiBest   = "\x1b[32m"   // Green
+	ansiTurn   = "\x1b[1;33m" // Bold yellow
+	ansiMarker = "\x1b[1;36m" // Bold cyan, for the start and end tiles
+)
+
+// Colours of the tiles in RenderPNG
+var (
+	wallColor   = color.RGBA{0x40, 0x40, 0x40, 0xff}
+	openColor   = color.RGBA{0xf0, 0xf0, 0xf0, 0xff}
+	bestColor   = color.RGBA{0x31, 0xa3, 0x54, 0xff}
+	turnColor   = color.RGBA{0xfe, 0xb2, 0x4c, 0xff}
+	markerColor = color.RGBA{0x31, 0x82, 0xbd, 0xff}
+)
+
+// tileSize is the width and height in pixels of a tile in RenderPNG
+const tileSize = 6
+
+// arrows shows the heading a route leaves a turn point in
+var arrows = map[grid.Direction]byte{
+	grid.North: '^',
+	grid.East:  '>',
+	grid.South: 'v',
+	grid.West:  '<',
+}
+
+// turnPoints indexes a route's turn points by tile
+func turnPoints(route Route) map[grid.Point]grid.Direction {
+	turns := make(map[grid.Point]grid.Direction, len(route.TurnPoints))
+	for _, t := range route.TurnPoints {
+		turns[t.Pos] = t.Dir
+	}
+	return turns
+}
+
+// RenderText writes the maze with the tiles on best paths drawn as green
+// O's and the turn points of route as yellow arrows showing the new
+// heading, followed by a line summing up what is drawn.
+func RenderText(w io.Writer, maze *grid.Grid[byte], best map[grid.Point]bool, route Route) error {
+	bw := bufio.NewWriter(w)
+	turns := turnPoints(route)
+
+	for y := range maze.Height() {
+		// Colours only change at the edges of runs of alike tiles
+		current := ""
+		for x := range maze.Width() {
+			pos := grid.Point{X: x, Y: y}
+			tile := maze.At(pos)
+			dir, turned := turns[pos]
+
+			colour, char := "", tile
+			switch {
+			case tile == 'S' || tile == 'E':
+				colour = ansiMarker
+			case turned:
+				colour, char = ansiTurn, arrows[dir]
+			case best[pos]:
+				colour, char = ansiBest, 'O'
+			case tile == '#':
+				colour = ansiWall
+			}
+
+			if colour != current {
+				if current != "" {
+					bw.WriteString(ansiReset)
+				}
+				bw.WriteString(colour)
+				current = colour
+			}
+			bw.WriteByte(char)
+		}
+		if current != "" {
+			bw.WriteString(ansiReset)
+		}
+		bw.WriteByte('\n')
+	}
+
+	fmt.Fprintf(bw, "%d tiles on best paths; route scores %d with %d turns\n", len(best), route.Score, route.Turns)
+	return bw.Flush()
+}
+
+// RenderPNG draws the maze as an image, colouring the tiles in the same way
+// as RenderText
+func RenderPNG(w io.Writer, maze *grid.Grid[byte], best map[grid.Point]bool, route Route) error {
+	img := image.NewRGBA(image.Rect(0, 0, maze.Width()*tileSize, maze.Height()*tileSize))
+	turns := turnPoints(route)
+
+	for pos, tile := range maze.All() {
+		var c color.RGBA
+		_, turned := turns[pos]
+		switch {
+		case tile == 'S' || tile == 'E':
+			c = markerColor
+		case turned:
+			c = turnColor
+		case best[pos]:
+			c = bestColor
+		case tile == '#':
+			c = wallColor
+		default:
+			c = openColor
+		}
+
+		for dy := range tileSize {
+			for dx := range tileSize {
+				img.SetRGBA(pos.X*tileSize+dx, pos.Y*tileSize+dy, c)
+			}
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// Render draws the maze in the format implied by the extension of the file
+// it is destined for: an image for .png and ANSI text otherwise.
+func Render(w io.Writer, path string, maze *grid.Grid[byte], best map[grid.Point]bool, route Route) error {
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		return RenderPNG(w, maze, best, route)
+	}
+	return RenderText(w, maze, best, route)
+}
+
+// writeRenderFile renders the maze to the named file, or to standard error
+// for "-"
+func writeRenderFile(path string, maze *grid.Grid[byte], best map[grid.Point]bool, route Route) error {
+	if path == "-" {
+		return Render(os.Stderr, path, maze, best, route)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating rendering: %w", err)
+	}
+	if err := Render(file, path, maze, best, route); err != nil {
+		file.Close()
+		return fmt.Errorf("writing rendering: %w", err)
+	}
+	return file.Close()
+}
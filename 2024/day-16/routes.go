@@ -0,0 +1,142 @@
+package day16
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+
+	"aoc2024/grid"
+	"aoc2024/search"
+)
+
+// deadEnd is the heuristic value of states from which the end tile cannot
+// be reached, large enough that they are never explored first
+const deadEnd = 1 << 40
+
+// Route is one way through the maze: the tiles it visits in order, its
+// score, the number of 90° turns it makes and where it makes them
+type Route struct {
+	Tiles      []grid.Point
+	Score      int
+	Turns      int
+	TurnPoints []TurnPoint
+}
+
+// TurnPoint is a tile where a route changes heading and the heading it
+// leaves the tile in
+type TurnPoint struct {
+	Pos grid.Point
+	Dir grid.Direction
+}
+
+// FindBestRoutes returns up to k of the cheapest routes from the start tile
+// to the end tile, cheapest first. Routes that visit the same tiles in the
+// same order count once, at their lowest score.
+func FindBestRoutes(maze *grid.Grid[byte], rules Rules, k int) []Route {
+	if k <= 0 {
+		return nil
+	}
+
+	start, end := findStartAndEndPositions(maze)
+	sp := newStateSpace(maze)
+	toEnd := scoresToEnd(maze, end, rules.Costs)
+	heuristic := func(s State) int {
+		if score := toEnd[sp.index(s)]; score != search.Unreachable {
+			return score
+		}
+		return deadEnd
+	}
+
+	// Paths that only turn differently yield the same route, so ask for
+	// more paths until there are enough distinct routes or no more paths
+	for paths := k; ; paths *= 2 {
+		found := search.KShortestPaths(
+			State{pos: start, dir: rules.Facing},
+			getPossibleMoves(maze, rules.Costs),
+			func(s State) bool { return s.pos == end },
+			heuristic,
+			paths,
+		)
+
+		var routes []Route
+		for _, path := range found {
+			route := newRoute(path)
+			if !slices.ContainsFunc(routes, func(r Route) bool { return slices.Equal(r.Tiles, route.Tiles) }) {
+				routes = append(routes, route)
+			}
+		}
+		if len(routes) >= k || len(found) < paths {
+			return routes[:min(k, len(routes))]
+		}
+	}
+}
+
+// WriteRoutes lists routes one per line with their score, turns and length
+func WriteRoutes(w io.Writer, routes []Route) {
+	for i, route := range routes {
+		fmt.Fprintf(w, "route %d: score %d, %d turns, %d tiles\n", i+1, route.Score, route.Turns, len(route.Tiles))
+	}
+}
+
+// newRoute describes a path through the state space as a route
+func newRoute(path search.Path[State]) Route {
+	route := Route{Score: path.Cost}
+	for i, state := range path.States {
+		if i == 0 || state.pos != path.States[i-1].pos {
+			route.Tiles = append(route.Tiles, state.pos)
+			continue
+		}
+
+		prev := path.States[i-1]
+		switch state.dir {
+		case prev.dir:
+			continue
+		case prev.dir.Reverse():
+			route.Turns += 2
+		default:
+			route.Turns++
+		}
+		if n := len(route.TurnPoints); n > 0 && route.TurnPoints[n-1].Pos == state.pos {
+			route.TurnPoints[n-1].Dir = state.dir
+		} else {
+			route.TurnPoints = append(route.TurnPoints, TurnPoint{state.pos, state.dir})
+		}
+	}
+	return route
+}
+
+// getReverseMoves returns the moves that lead into a state, the reverse of
+// getPossibleMoves. The forward search stops at the end tile, so no move
+// starts there.
+func getReverseMoves(maze *grid.Grid[byte], costs CostModel, end grid.Point) search.Edges[State] {
+	turns := costs.turns()
+	return func(current State) iter.Seq2[State, int] {
+		return func(yield func(State, int) bool) {
+			if prev := current.pos.Move(current.dir.Reverse()); prev != end && isValidMove(maze, prev) {
+				if !yield(State{prev, current.dir}, costs.enterCost(maze.At(current.pos))) {
+					return
+				}
+			}
+			if current.pos == end {
+				return
+			}
+			for _, t := range turns {
+				if !yield(State{current.pos, t.undo(current.dir)}, t.cost) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// scoresToEnd returns the lowest score from each state to the end tile,
+// found by searching backwards from the end facing any way
+func scoresToEnd(maze *grid.Grid[byte], end grid.Point, costs CostModel) []int {
+	var arrivals []State
+	for _, dir := range grid.Directions {
+		arrivals = append(arrivals, State{end, dir})
+	}
+	sp := newStateSpace(maze)
+	return search.Distances(arrivals, getReverseMoves(maze, costs, end), sp.index, sp.size(), nil)
+}
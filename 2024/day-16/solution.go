@@ -1,293 +1,280 @@
-package main
+package day16
 
 import (
-	"bufio"
-	"container/heap"
+	_ "embed"
+	"errors"
 	"fmt"
 	"io"
-	"os"
-)
-
-// Direction represents the cardinal directions
-type Direction int
+	"iter"
 
-const (
-	North Direction = iota
-	East
-	South
-	West
+	"aoc2024/aoc"
+	"aoc2024/grid"
+	"aoc2024/search"
 )
 
-// Position represents a point in the maze
-type Position struct {
-	x, y int
-}
-
+// State is a reindeer's position in the maze together with the direction
+// it is facing
 type State struct {
-	pos      Position
-	dir      Direction
-	cost     int
-	index    int
-	previous *State
-}
-
-// PreviousState stores information about how we reached a state
-type PreviousState struct {
-	pos Position
-	dir Direction
-}
-
-// PriorityQueue implementation
-type PriorityQueue []*State
-
-func (pq PriorityQueue) Len() int { return len(pq) }
-func (pq PriorityQueue) Less(i, j int) bool {
-	return pq[i].cost < pq[j].cost
-}
-
-func (pq PriorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-	pq[i].index = i
-	pq[j].index = j
+	pos grid.Point
+	dir grid.Direction
 }
 
-func (pq *PriorityQueue) Push(x interface{}) {
-	n := len(*pq)
-	item := x.(*State)
-	item.index = n
-	*pq = append(*pq, item)
+// CostModel gives the score of each of the reindeer's moves
+type CostModel struct {
+	Forward          int  // Stepping onto the next tile
+	Clockwise        int  // Turning 90° to the right
+	CounterClockwise int  // Turning 90° to the left
+	UTurn            int  // Turning around in one move, or 0 to allow only two 90° turns
+	TileWeights      bool // Stepping onto a digit tile costs Forward times the digit
 }
 
-func (pq *PriorityQueue) Pop() interface{} {
-	old := *pq
-	n := len(old)
-	item := old[n-1]
-	old[n-1] = nil
-	item.index = -1
-	*pq = old[0 : n-1]
-	return item
+// Rules set how the reindeer moves through the maze
+type Rules struct {
+	Costs  CostModel
+	Facing grid.Direction // The way the reindeer faces on the start tile
 }
 
-// PathInfo stores information about a state in the optimal path
-type PathInfo struct {
-	next map[string]bool // Stores next possible states in optimal paths
-	cost int
+// DefaultRules are the rules of the puzzle
+var DefaultRules = Rules{
+	Costs:  CostModel{Forward: 1, Clockwise: 1000, CounterClockwise: 1000},
+	Facing: grid.East,
 }
 
-// StateKey creates a unique key for visited states
-func stateKey(pos Position, dir Direction) string {
-	return fmt.Sprintf("%d,%d,%d", pos.x, pos.y, dir)
+// Validate rejects negative costs, which the searches cannot handle
+func (m CostModel) Validate() error {
+	if m.Forward < 0 || m.Clockwise < 0 || m.CounterClockwise < 0 || m.UTurn < 0 {
+		return fmt.Errorf("move costs must not be negative: %+v", m)
+	}
+	return nil
 }
 
-// GetNextPosition returns the next position given current position and direction
-func getNextPosition(pos Position, dir Direction) Position {
-	switch dir {
-	case North:
-		return Position{pos.x, pos.y - 1}
-	case East:
-		return Position{pos.x + 1, pos.y}
-	case South:
-		return Position{pos.x, pos.y + 1}
-	case West:
-		return Position{pos.x - 1, pos.y}
+// enterCost returns the score of stepping onto a tile
+func (m CostModel) enterCost(tile byte) int {
+	if m.TileWeights && tile >= '0' && tile <= '9' {
+		return m.Forward * int(tile-'0')
 	}
-	return pos
+	return m.Forward
 }
 
-// RotateClockwise returns the new direction after rotating 90° clockwise
-func rotateClockwise(dir Direction) Direction {
-	return (dir + 1) % 4
+// turn is a change of heading in place and its score
+type turn struct {
+	rotate func(grid.Direction) grid.Direction
+	undo   func(grid.Direction) grid.Direction
+	cost   int
 }
 
-// RotateCounterClockwise returns the new direction after rotating 90° counterclockwise
-func rotateCounterClockwise(dir Direction) Direction {
-	return (dir + 3) % 4
+// turns returns the turns the cost model allows
+func (m CostModel) turns() []turn {
+	turns := []turn{
+		{grid.Direction.Clockwise, grid.Direction.CounterClockwise, m.Clockwise},
+		{grid.Direction.CounterClockwise, grid.Direction.Clockwise, m.CounterClockwise},
+	}
+	if m.UTurn > 0 {
+		turns = append(turns, turn{grid.Direction.Reverse, grid.Direction.Reverse, m.UTurn})
+	}
+	return turns
 }
 
-func FindLowestScoreWithPaths(maze []string) (int, map[Position]bool) {
+// FindLowestScoreWithPaths returns the lowest score from the start tile to
+// the end tile, along with every tile that is part of at least one path with
+// that score. The score is -1 if the end cannot be reached.
+func FindLowestScoreWithPaths(maze *grid.Grid[byte], rules Rules) (int, map[grid.Point]bool) {
 	start, end := findStartAndEndPositions(maze)
-	pq := initializePriorityQueue(start)
-	distTo, pathInfo := initializeMaps()
-	lowestEndCost := -1
-
-	lowestEndCost = runDijkstra(maze, pq, distTo, pathInfo, start, end, lowestEndCost)
-	optimalCells := findOptimalCells(pathInfo, distTo, start, end, lowestEndCost)
+	sp := newStateSpace(maze)
+	scores := search.Distances(
+		[]State{{pos: start, dir: rules.Facing}},
+		getPossibleMoves(maze, rules.Costs),
+		sp.index,
+		sp.size(),
+		func(s State) bool { return s.pos == end },
+	)
+
+	lowest := search.Unreachable
+	for _, dir := range grid.Directions {
+		score := scores[sp.index(State{end, dir})]
+		if score != search.Unreachable && (lowest == search.Unreachable || score < lowest) {
+			lowest = score
+		}
+	}
+	if lowest == search.Unreachable {
+		return -1, map[grid.Point]bool{}
+	}
 
-	return lowestEndCost, optimalCells
+	return lowest, findOptimalCells(maze, rules.Costs, sp, scores, end, lowest)
 }
 
-func findStartAndEndPositions(maze []string) (Position, Position) {
-	var start, end Position
-	for y := range maze {
-		for x := range maze[y] {
-			switch maze[y][x] {
-			case 'S':
-				start = Position{x, y}
-			case 'E':
-				end = Position{x, y}
-			}
+func findStartAndEndPositions(maze *grid.Grid[byte]) (grid.Point, grid.Point) {
+	var start, end grid.Point
+	for pos, tile := range maze.All() {
+		switch tile {
+		case 'S':
+			start = pos
+		case 'E':
+			end = pos
 		}
 	}
 	return start, end
 }
 
-func initializePriorityQueue(start Position) PriorityQueue {
-	pq := make(PriorityQueue, 0)
-	heap.Init(&pq)
-	heap.Push(&pq, &State{pos: start, dir: East, cost: 0})
-	return pq
+// getPossibleMoves returns the moves available from a state: stepping
+// forward onto an open tile, or turning in place as the cost model allows
+func getPossibleMoves(maze *grid.Grid[byte], costs CostModel) search.Edges[State] {
+	turns := costs.turns()
+	return func(current State) iter.Seq2[State, int] {
+		return func(yield func(State, int) bool) {
+			if next := current.pos.Move(current.dir); isValidMove(maze, next) {
+				if !yield(State{next, current.dir}, costs.enterCost(maze.At(next))) {
+					return
+				}
+			}
+			for _, t := range turns {
+				if !yield(State{current.pos, t.rotate(current.dir)}, t.cost) {
+					return
+				}
+			}
+		}
+	}
 }
 
-func initializeMaps() (map[string]int, map[string]*PathInfo) {
-	return make(map[string]int), make(map[string]*PathInfo)
+func isValidMove(maze *grid.Grid[byte], pos grid.Point) bool {
+	tile, ok := maze.Get(pos)
+	return ok && tile != '#'
 }
 
-func runDijkstra(maze []string, pq PriorityQueue, distTo map[string]int, pathInfo map[string]*PathInfo, start, end Position, lowestEndCost int) int {
-	for pq.Len() > 0 {
-		current := heap.Pop(&pq).(*State)
-		currentKey := stateKey(current.pos, current.dir)
-
-		if cost, exists := distTo[currentKey]; exists && cost < current.cost {
-			continue
+// findOptimalCells walks back from the end states reached with the lowest
+// score, following every move into a state that scores exactly what the
+// state does, and collects the tiles of the states it visits
+func findOptimalCells(maze *grid.Grid[byte], costs CostModel, sp stateSpace, scores []int, end grid.Point, lowest int) map[grid.Point]bool {
+	onPath := make([]bool, sp.size())
+	var stack []State
+	for _, dir := range grid.Directions {
+		if goal := (State{end, dir}); scores[sp.index(goal)] == lowest {
+			onPath[sp.index(goal)] = true
+			stack = append(stack, goal)
 		}
+	}
 
-		if current.pos == end {
-			if lowestEndCost == -1 || current.cost < lowestEndCost {
-				lowestEndCost = current.cost
-			}
-			continue
-		}
+	reverse := getReverseMoves(maze, costs, end)
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
 
-		if _, exists := pathInfo[currentKey]; !exists {
-			pathInfo[currentKey] = &PathInfo{
-				cost: current.cost,
-				next: make(map[string]bool),
+		for prev, cost := range reverse(current) {
+			i := sp.index(prev)
+			if !onPath[i] && scores[i] != search.Unreachable && scores[i]+cost == scores[sp.index(current)] {
+				onPath[i] = true
+				stack = append(stack, prev)
 			}
 		}
+	}
 
-		moves := getPossibleMoves(current)
-		for _, move := range moves {
-			if isValidMove(maze, move.pos) {
-				nextCost := current.cost + move.cost
-				nextKey := stateKey(move.pos, move.dir)
-
-				if nextCost <= lowestEndCost || lowestEndCost == -1 {
-					if cost, exists := distTo[nextKey]; !exists || nextCost < cost {
-						distTo[nextKey] = nextCost
-						pathInfo[currentKey].next[nextKey] = true
-						heap.Push(&pq, &State{
-							pos:  move.pos,
-							dir:  move.dir,
-							cost: nextCost,
-						})
-					} else if nextCost == cost {
-						pathInfo[currentKey].next[nextKey] = true
-					}
-				}
-			}
+	optimalCells := make(map[grid.Point]bool)
+	for i, ok := range onPath {
+		if ok {
+			optimalCells[sp.state(i).pos] = true
 		}
 	}
-	return lowestEndCost
+	return optimalCells
 }
 
-func getPossibleMoves(current *State) []struct {
-	pos  Position
-	dir  Direction
-	cost int
-} {
-	return []struct {
-		pos  Position
-		dir  Direction
-		cost int
-	}{
-		{getNextPosition(current.pos, current.dir), current.dir, 1},
-		{current.pos, rotateClockwise(current.dir), 1000},
-		{current.pos, rotateCounterClockwise(current.dir), 1000},
+func ParseMaze(r io.Reader) (*grid.Grid[byte], error) {
+	maze, err := grid.Parse(r, grid.AsByte)
+	if err != nil {
+		return nil, fmt.Errorf("error reading maze: %w", err)
 	}
-}
 
-func isValidMove(maze []string, pos Position) bool {
-	return pos.y >= 0 && pos.y < len(maze) && pos.x >= 0 && pos.x < len(maze[0]) && maze[pos.y][pos.x] != '#'
+	return maze, nil
 }
 
-func findOptimalCells(pathInfo map[string]*PathInfo, distTo map[string]int, start, end Position, lowestEndCost int) map[Position]bool {
-	optimalCells := make(map[Position]bool)
-	seen := make(map[string]bool)
-
-	var dfs func(pos Position, dir Direction) bool
-	dfs = func(pos Position, dir Direction) bool {
-		if pos == end {
-			return true
-		}
+var errNoPath = errors.New("the end tile cannot be reached")
 
-		currentKey := stateKey(pos, dir)
-		if seen[currentKey] {
-			return false
-		}
-		seen[currentKey] = true
-		defer func() { seen[currentKey] = false }()
+// Options configure a Solver
+type Options struct {
+	// Rules are the rules Part1 and Part2 follow
+	Rules Rules
 
-		info := pathInfo[currentKey]
-		if info == nil {
-			return false
-		}
+	// RenderPath names a file that Part2 draws the best paths to when set,
+	// as an image for .png files and ANSI text otherwise, or standard error
+	// for "-"
+	RenderPath string
 
-		reachesEnd := false
-		for nextKey := range info.next {
-			var nextPos Position
-			var nextDir Direction
-			fmt.Sscanf(nextKey, "%d,%d,%d", &nextPos.x, &nextPos.y, &nextDir)
+	// RouteCount is the number of cheapest routes Part1 lists on RouteLog
+	RouteCount int
 
-			if distTo[nextKey] <= lowestEndCost && dfs(nextPos, nextDir) {
-				reachesEnd = true
-			}
-		}
+	// RouteLog receives the cheapest routes when RouteCount is positive
+	RouteLog io.Writer
+}
 
-		if reachesEnd {
-			optimalCells[pos] = true
-		}
-		return reachesEnd
-	}
+// DefaultOptions solve the puzzle as set, without listing or drawing
+var DefaultOptions = Options{Rules: DefaultRules}
 
-	dfs(start, East)
-	optimalCells[end] = true
+//go:embed input.txt
+var input string
 
-	return optimalCells
+func init() {
+	aoc.Register(2024, 16, func() aoc.Solver { return &Solver{options: DefaultOptions} })
+	aoc.Embed(2024, 16, input)
 }
 
-func ParseMaze(r io.Reader) ([]string, error) {
-	var maze []string
-	scanner := bufio.NewScanner(r)
+// Solver solves day 16 using the reindeer maze
+type Solver struct {
+	options Options
+	maze    *grid.Grid[byte]
+}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line != "" {
-			maze = append(maze, line)
-		}
+// NewSolver returns a solver following options, which must have valid
+// costs and somewhere to list routes if any are asked for
+func NewSolver(options Options) (*Solver, error) {
+	if err := options.Rules.Costs.Validate(); err != nil {
+		return nil, err
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading maze: %w", err)
+	if options.RouteCount < 0 {
+		return nil, fmt.Errorf("negative route count %d", options.RouteCount)
 	}
-
-	return maze, nil
+	if options.RouteCount > 0 && options.RouteLog == nil {
+		return nil, errors.New("routes asked for without a log to list them on")
+	}
+	return &Solver{options: options}, nil
 }
 
-func main() {
-	file, err := os.Open("input.txt")
+// Parse reads the rows of the maze
+func (s *Solver) Parse(r io.Reader) error {
+	maze, err := ParseMaze(r)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return err
 	}
-	defer file.Close()
 
-	maze, err := ParseMaze(file)
-	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
+	s.maze = maze
+	return nil
+}
+
+// Part1 returns the lowest score a reindeer could possibly get
+func (s *Solver) Part1() (aoc.Answer, error) {
+	lowestScore, _ := FindLowestScoreWithPaths(s.maze, s.options.Rules)
+	if lowestScore < 0 {
+		return aoc.Answer{}, errNoPath
+	}
+	if s.options.RouteCount > 0 {
+		WriteRoutes(s.options.RouteLog, FindBestRoutes(s.maze, s.options.Rules, s.options.RouteCount))
 	}
+	return aoc.Int(lowestScore), nil
+}
 
-	lowestScore, optimalCells := FindLowestScoreWithPaths(maze)
-	fmt.Println("Lowest score:", lowestScore)
-	fmt.Println("Number of cells on optimal paths:", len(optimalCells))
+// Part2 returns the number of tiles that are part of at least one best path
+func (s *Solver) Part2() (aoc.Answer, error) {
+	lowestScore, optimalCells := FindLowestScoreWithPaths(s.maze, s.options.Rules)
+	if lowestScore < 0 {
+		return aoc.Answer{}, errNoPath
+	}
+	if s.options.RenderPath != "" {
+		// Mark the turns of the cheapest route, or none if it is missing
+		var route Route
+		if routes := FindBestRoutes(s.maze, s.options.Rules, 1); len(routes) > 0 {
+			route = routes[0]
+		}
+		if err := writeRenderFile(s.options.RenderPath, s.maze, optimalCells, route); err != nil {
+			return aoc.Answer{}, err
+		}
+	}
+	return aoc.Int(len(optimalCells)), nil
 }
@@ -1,8 +1,14 @@
-package main
+package day16
 
 import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"regexp"
 	"strings"
 	"testing"
+
+	"aoc2024/grid"
 )
 
 func TestFindLowestScore(t *testing.T) {
@@ -64,7 +70,7 @@ func TestFindLowestScore(t *testing.T) {
 				t.Fatalf("ParseMaze() error = %v", err)
 			}
 
-			lowestScore, bestPathTiles := FindLowestScoreWithPaths(maze)
+			lowestScore, bestPathTiles := FindLowestScoreWithPaths(maze, DefaultRules)
 			if lowestScore != tt.expectedLowestScore {
 				t.Errorf("FindLowestScore() = %v, want %v", lowestScore, tt.expectedLowestScore)
 			}
@@ -75,3 +81,205 @@ func TestFindLowestScore(t *testing.T) {
 		})
 	}
 }
+
+func TestFindBestRoutes(t *testing.T) {
+	maze, err := ParseMaze(strings.NewReader(`###############
+#.......#....E#
+#.#.###.#.###.#
+#.....#.#...#.#
+#.###.#####.#.#
+#.#.#.......#.#
+#.#.#####.###.#
+#...........#.#
+###.#.#####.#.#
+#...#.....#.#.#
+#.#.#.###.#.#.#
+#.....#...#.#.#
+#.###.#.#.#.#.#
+#S..#.....#...#
+###############`))
+	if err != nil {
+		t.Fatalf("ParseMaze() error = %v", err)
+	}
+
+	routes := FindBestRoutes(maze, DefaultRules, 4)
+	if len(routes) != 4 {
+		t.Fatalf("FindBestRoutes() returned %d routes, want 4", len(routes))
+	}
+
+	// The three best routes together cover every tile on a best path
+	onBestPath := make(map[grid.Point]bool)
+	for i, route := range routes {
+		wantScore, wantTurns := 7036, 7
+		if i == 3 {
+			wantScore, wantTurns = 9038, 9
+		}
+		if route.Score != wantScore || route.Turns != wantTurns {
+			t.Errorf("route %d: score %d with %d turns, want %d with %d", i+1, route.Score, route.Turns, wantScore, wantTurns)
+		}
+		if want := route.Score - 1000*route.Turns + 1; len(route.Tiles) != want {
+			t.Errorf("route %d visits %d tiles, want %d", i+1, len(route.Tiles), want)
+		}
+		for _, tile := range route.Tiles {
+			if i < 3 {
+				onBestPath[tile] = true
+			}
+		}
+	}
+	if len(onBestPath) != 45 {
+		t.Errorf("best routes cover %d tiles, want 45", len(onBestPath))
+	}
+
+	var list strings.Builder
+	WriteRoutes(&list, routes[:1])
+	if want := "route 1: score 7036, 7 turns, 37 tiles\n"; list.String() != want {
+		t.Errorf("WriteRoutes() = %q, want %q", list.String(), want)
+	}
+}
+
+func TestCostModels(t *testing.T) {
+	// A corridor that can only be walked westwards, with a weighted tile
+	corridor := `#####
+#E9S#
+#####`
+
+	tests := []struct {
+		name  string
+		rules Rules
+		want  int
+	}{
+		{"default", DefaultRules, 2002},
+		{"cheap clockwise turns", Rules{Costs: CostModel{Forward: 1, Clockwise: 10, CounterClockwise: 1000}, Facing: grid.East}, 22},
+		{"u-turn", Rules{Costs: CostModel{Forward: 1, Clockwise: 1000, CounterClockwise: 1000, UTurn: 1500}, Facing: grid.East}, 1502},
+		{"facing west", Rules{Costs: DefaultRules.Costs, Facing: grid.West}, 2},
+		{"tile weights", Rules{Costs: CostModel{Forward: 2, Clockwise: 1000, CounterClockwise: 1000, TileWeights: true}, Facing: grid.West}, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maze, err := ParseMaze(strings.NewReader(corridor))
+			if err != nil {
+				t.Fatalf("ParseMaze() error = %v", err)
+			}
+
+			score, tiles := FindLowestScoreWithPaths(maze, tt.rules)
+			if score != tt.want || len(tiles) != 3 {
+				t.Errorf("FindLowestScoreWithPaths() = %d over %d tiles, want %d over 3", score, len(tiles), tt.want)
+			}
+
+			routes := FindBestRoutes(maze, tt.rules, 1)
+			if len(routes) != 1 || routes[0].Score != tt.want {
+				t.Errorf("FindBestRoutes() = %+v, want one route scoring %d", routes, tt.want)
+			}
+		})
+	}
+
+	if err := (CostModel{Forward: -1}).Validate(); err == nil {
+		t.Error("Validate() accepted a negative cost")
+	}
+}
+
+func TestNewSolver(t *testing.T) {
+	corridor := `#####
+#E9S#
+#####`
+
+	var log bytes.Buffer
+	tests := []struct {
+		name    string
+		options Options
+		want    string // Part 1, or empty if NewSolver must fail
+	}{
+		{"default", DefaultOptions, "2002"},
+		{"facing west", Options{Rules: Rules{Costs: DefaultRules.Costs, Facing: grid.West}}, "2"},
+		{"listing routes", Options{Rules: DefaultRules, RouteCount: 1, RouteLog: &log}, "2002"},
+		{"negative cost", Options{Rules: Rules{Costs: CostModel{Forward: -1}}}, ""},
+		{"negative route count", Options{Rules: DefaultRules, RouteCount: -1}, ""},
+		{"routes without a log", Options{Rules: DefaultRules, RouteCount: 2}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSolver(tt.options)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatal("NewSolver() accepted invalid options")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSolver() error = %v", err)
+			}
+
+			if err := s.Parse(strings.NewReader(corridor)); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := s.Part1(); err != nil || got.String() != tt.want {
+				t.Errorf("Part1() = %v, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+
+	if want := "route 1: score 2002, 2 turns, 3 tiles\n"; log.String() != want {
+		t.Errorf("route log = %q, want %q", log.String(), want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	maze, err := ParseMaze(strings.NewReader(`#####
+#..E#
+#.###
+#S..#
+#####`))
+	if err != nil {
+		t.Fatalf("ParseMaze() error = %v", err)
+	}
+	_, best := FindLowestScoreWithPaths(maze, DefaultRules)
+	route := FindBestRoutes(maze, DefaultRules, 1)[0]
+
+	var text strings.Builder
+	if err := RenderText(&text, maze, best, route); err != nil {
+		t.Fatal(err)
+	}
+	plain := regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(text.String(), "")
+	want := `#####
+#>OE#
+#O###
+#S..#
+#####
+5 tiles on best paths; route scores 2004 with 2 turns
+`
+	if plain != want {
+		t.Errorf("RenderText() without colours =\n%s\nwant\n%s", plain, want)
+	}
+	if !strings.Contains(text.String(), ansiTurn+">"+ansiReset) {
+		t.Errorf("RenderText() does not colour the turn point: %q", text.String())
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, "maze.png", maze, best, route); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Render() did not write a PNG: %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != 5*tileSize || size.Y != 5*tileSize {
+		t.Errorf("image is %v, want %dx%d", size, 5*tileSize, 5*tileSize)
+	}
+	for _, tt := range []struct {
+		pos  grid.Point
+		want color.RGBA
+	}{
+		{grid.Point{X: 1, Y: 3}, markerColor},
+		{grid.Point{X: 1, Y: 2}, bestColor},
+		{grid.Point{X: 1, Y: 1}, turnColor},
+		{grid.Point{X: 2, Y: 1}, bestColor},
+		{grid.Point{X: 2, Y: 3}, openColor},
+		{grid.Point{X: 0, Y: 0}, wallColor},
+	} {
+		if got := img.At(tt.pos.X*tileSize, tt.pos.Y*tileSize); got != tt.want {
+			t.Errorf("tile %v is %v, want %v", tt.pos, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package day16
+
+import "aoc2024/grid"
+
+// stateSpace numbers the states of a maze so that tables about them, such
+// as the scores from search.Distances, are slices indexed by (y, x,
+// direction) instead of maps
+type stateSpace struct {
+	width, height int
+}
+
+// newStateSpace returns the state space of a maze
+func newStateSpace(maze *grid.Grid[byte]) stateSpace {
+	return stateSpace{maze.Width(), maze.Height()}
+}
+
+// size returns the number of states
+func (sp stateSpace) size() int {
+	return sp.width * sp.height * len(grid.Directions)
+}
+
+// index returns the number of a state
+func (sp stateSpace) index(s State) int {
+	return (s.pos.Y*sp.width+s.pos.X)*len(grid.Directions) + int(s.dir)
+}
+
+// state returns the state with a number
+func (sp stateSpace) state(i int) State {
+	dir := grid.Direction(i % len(grid.Directions))
+	i /= len(grid.Directions)
+	return State{pos: grid.Point{X: i % sp.width, Y: i / sp.width}, dir: dir}
+}
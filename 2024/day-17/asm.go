@@ -0,0 +1,159 @@
+package day17
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AsmError reports a problem with a program's source and the line it is on
+type AsmError struct {
+	Line int
+	Msg  string
+}
+
+func (e *AsmError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// asmLine is an instruction of the source waiting for its operand to be
+// resolved once every label is known
+type asmLine struct {
+	line    int
+	opcode  int
+	operand string
+}
+
+// Assemble translates assembler source into a program. Each line holds an
+// optional label ending in a colon, an instruction such as "adv 3", "out a"
+// or "jnz loop", and an optional comment after ';' or '#'. A label made of
+// digits is an address, as printed by Disassemble, and must match the
+// position of the instruction that follows, so listings assemble back into
+// the program they came from.
+func Assemble(src string) ([]int, error) {
+	labels := make(map[string]int)
+	var lines []asmLine
+
+	for i, text := range strings.Split(src, "\n") {
+		lineNo := i + 1
+		if cut := strings.IndexAny(text, ";#"); cut >= 0 {
+			text = text[:cut]
+		}
+		fields := strings.Fields(text)
+
+		for len(fields) > 0 && strings.HasSuffix(fields[0], ":") {
+			label := strings.TrimSuffix(fields[0], ":")
+			fields = fields[1:]
+			addr := 2 * len(lines)
+
+			if n, err := strconv.Atoi(label); err == nil {
+				if n != addr {
+					return nil, &AsmError{lineNo, fmt.Sprintf("address %d does not match position %d", n, addr)}
+				}
+				continue
+			}
+			if label == "" {
+				return nil, &AsmError{lineNo, "empty label"}
+			}
+			if _, exists := labels[label]; exists {
+				return nil, &AsmError{lineNo, fmt.Sprintf("label %q defined twice", label)}
+			}
+			labels[label] = addr
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		opcode := -1
+		for op, mnemonic := range mnemonics {
+			if strings.EqualFold(fields[0], mnemonic) {
+				opcode = op
+			}
+		}
+		if opcode < 0 {
+			return nil, &AsmError{lineNo, fmt.Sprintf("unknown instruction %q", fields[0])}
+		}
+
+		switch {
+		case len(fields) == 1 && opcode == OpBXC:
+			// bxc ignores its operand, so it may be left out
+			lines = append(lines, asmLine{lineNo, opcode, "0"})
+		case len(fields) == 2:
+			lines = append(lines, asmLine{lineNo, opcode, fields[1]})
+		default:
+			return nil, &AsmError{lineNo, fmt.Sprintf("%s takes exactly one operand", mnemonics[opcode])}
+		}
+	}
+
+	code := make([]int, 0, 2*len(lines))
+	for _, l := range lines {
+		operand, err := resolveOperand(l, labels)
+		if err != nil {
+			return nil, err
+		}
+		code = append(code, l.opcode, operand)
+	}
+	return code, nil
+}
+
+// resolveOperand turns the operand of an instruction into its 3-bit value
+func resolveOperand(l asmLine, labels map[string]int) (int, error) {
+	if takesCombo(l.opcode) {
+		for register, name := range registerNames {
+			if strings.EqualFold(l.operand, name) {
+				return register, nil
+			}
+		}
+	}
+
+	value, err := strconv.Atoi(l.operand)
+	if err != nil {
+		addr, ok := labels[l.operand]
+		if !ok {
+			return 0, &AsmError{l.line, fmt.Sprintf("unknown operand %q", l.operand)}
+		}
+		value = addr
+	}
+
+	switch {
+	case value < 0 || value > 7:
+		return 0, &AsmError{l.line, fmt.Sprintf("operand %s does not fit in 3 bits", l.operand)}
+	case takesCombo(l.opcode) && value == 7:
+		return 0, &AsmError{l.line, "combo operand 7 is reserved"}
+	}
+	return value, nil
+}
+
+// FormatProgram writes a program as the "Program:" line of the puzzle input
+func FormatProgram(code []int) string {
+	return "Program: " + strings.Join(formatOutput(code), ",")
+}
+
+// ParseProgram reads the comma separated values of a "Program:" line. The
+// program must be made of whole instructions and must not use the reserved
+// combo operand 7.
+func ParseProgram(text string) ([]int, error) {
+	fields := strings.Fields(text)
+	if len(fields) != 2 || fields[0] != "Program:" {
+		return nil, fmt.Errorf("expected \"Program: <values>\", got %q", text)
+	}
+
+	var code []int
+	for _, s := range strings.Split(fields[1], ",") {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n > 7 {
+			return nil, fmt.Errorf("invalid program value %q", s)
+		}
+		code = append(code, n)
+	}
+
+	if len(code)%2 != 0 {
+		return nil, fmt.Errorf("program has an odd number of values (%d)", len(code))
+	}
+	for _, in := range Decode(code) {
+		if takesCombo(in.Opcode) && in.Operand == 7 {
+			return nil, fmt.Errorf("%s at address %d uses the reserved combo operand 7", mnemonics[in.Opcode], in.Addr)
+		}
+	}
+	return code, nil
+}
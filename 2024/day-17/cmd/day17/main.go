@@ -0,0 +1,23 @@
+// Command day17 solves Advent of Code 2024 day 17. It reads the file given
+// with -input, or standard input for "-input -", and otherwise uses the
+// puzzle input embedded in the binary. With -trace it also writes every
+// instruction that part one executes to standard error.
+package main
+
+import (
+	"flag"
+	"os"
+
+	"aoc2024/aoc"
+	day17 "aoc2024/day-17"
+)
+
+func main() {
+	trace := flag.Bool("trace", false, "write every instruction part one executes to standard error")
+
+	aoc.Main(2024, 17, func() {
+		if *trace {
+			day17.Trace = os.Stderr
+		}
+	})
+}
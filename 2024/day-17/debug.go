@@ -0,0 +1,127 @@
+package day17
+
+import (
+	"fmt"
+	"io"
+)
+
+// StopReason tells why the debugger handed control back
+type StopReason int
+
+const (
+	Halted     StopReason = iota // The program ran past its last instruction
+	Breakpoint                   // The instruction pointer reached a breakpoint
+	Watchpoint                   // A watched register changed value
+)
+
+// String returns a readable name for the stop reason
+func (r StopReason) String() string {
+	switch r {
+	case Halted:
+		return "halted"
+	case Breakpoint:
+		return "breakpoint"
+	case Watchpoint:
+		return "watchpoint"
+	default:
+		return fmt.Sprintf("StopReason(%d)", int(r))
+	}
+}
+
+// Debugger executes a computer's program one instruction at a time. It can
+// stop at breakpoints on the instruction pointer or when a watched register
+// changes, and can write a trace of every instruction it executes.
+type Debugger struct {
+	Computer *Computer
+	Trace    io.Writer // Receives one line per executed instruction when set
+	MaxSteps int       // Stops a runaway program with ErrNonTerminating when positive
+
+	breakpoints map[int]bool
+	watches     map[string]bool
+}
+
+// NewDebugger attaches a debugger to a computer
+func NewDebugger(c *Computer) *Debugger {
+	return &Debugger{
+		Computer:    c,
+		breakpoints: make(map[int]bool),
+		watches:     make(map[string]bool),
+	}
+}
+
+// Break sets a breakpoint at an instruction address
+func (d *Debugger) Break(ip int) {
+	d.breakpoints[ip] = true
+}
+
+// ClearBreak removes a breakpoint
+func (d *Debugger) ClearBreak(ip int) {
+	delete(d.breakpoints, ip)
+}
+
+// Watch stops execution whenever the named register ("A", "B" or "C")
+// changes value.
+func (d *Debugger) Watch(register string) error {
+	switch register {
+	case "A", "B", "C":
+		d.watches[register] = true
+		return nil
+	default:
+		return fmt.Errorf("unknown register %q", register)
+	}
+}
+
+// registers returns the current register values by name
+func (d *Debugger) registers() map[string]int {
+	return map[string]int{"A": d.Computer.A, "B": d.Computer.B, "C": d.Computer.C}
+}
+
+// Step executes the next instruction and returns it, or returns false if
+// the program has halted or failed.
+func (d *Debugger) Step() (Instruction, bool, error) {
+	c := d.Computer
+	if c.IP < 0 || c.IP >= len(c.Code)-1 {
+		return Instruction{}, false, nil
+	}
+	if d.MaxSteps > 0 && c.Steps >= d.MaxSteps {
+		return Instruction{}, false, &ExecError{IP: c.IP, Err: ErrNonTerminating}
+	}
+
+	in := Instruction{Addr: c.IP, Opcode: c.Code[c.IP], Operand: c.Code[c.IP+1]}
+	outputs := len(c.Output)
+	if _, err := c.executeInstruction(); err != nil {
+		return in, false, err
+	}
+
+	if d.Trace != nil {
+		fmt.Fprintf(d.Trace, "%02d: %-6s  A=%d B=%d C=%d", in.Addr, in, c.A, c.B, c.C)
+		if len(c.Output) > outputs {
+			fmt.Fprintf(d.Trace, "  out %d", c.Output[len(c.Output)-1])
+		}
+		fmt.Fprintln(d.Trace)
+	}
+	return in, true, nil
+}
+
+// Continue executes instructions until the program halts, the instruction
+// pointer reaches a breakpoint or a watched register changes. At least one
+// instruction is executed, so continuing from a breakpoint moves past it.
+// An error from the program is returned along with Halted.
+func (d *Debugger) Continue() (StopReason, error) {
+	for {
+		before := d.registers()
+		if _, ok, err := d.Step(); !ok {
+			return Halted, err
+		}
+
+		after := d.registers()
+		for register := range d.watches {
+			if before[register] != after[register] {
+				return Watchpoint, nil
+			}
+		}
+		if d.breakpoints[d.Computer.IP] {
+			return Breakpoint, nil
+		}
+	}
+}
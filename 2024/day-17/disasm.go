@@ -0,0 +1,100 @@
+package day17
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mnemonics names the operation codes in the order they are numbered
+var mnemonics = [...]string{"adv", "bxl", "bst", "jnz", "bxc", "out", "bdv", "cdv"}
+
+// registerNames names the registers that combo operands 4 to 6 refer to
+var registerNames = map[int]string{RegisterA: "A", RegisterB: "B", RegisterC: "C"}
+
+// Instruction is one opcode and operand pair of a program
+type Instruction struct {
+	Addr    int // Position of the opcode in the program
+	Opcode  int
+	Operand int
+}
+
+// takesCombo reports whether an opcode interprets its operand as a combo
+// operand rather than a literal
+func takesCombo(opcode int) bool {
+	switch opcode {
+	case OpADV, OpBST, OpOUT, OpBDV, OpCDV:
+		return true
+	default:
+		return false
+	}
+}
+
+// Decode splits a program into its instructions. A trailing opcode without
+// an operand can never execute and is left out.
+func Decode(code []int) []Instruction {
+	instructions := make([]Instruction, 0, len(code)/2)
+	for addr := 0; addr+1 < len(code); addr += 2 {
+		instructions = append(instructions, Instruction{Addr: addr, Opcode: code[addr], Operand: code[addr+1]})
+	}
+	return instructions
+}
+
+// String returns the instruction in assembler syntax, e.g. "adv 3", "out a"
+// or "jnz 0", naming the register of a combo operand in lower case.
+func (in Instruction) String() string {
+	if in.Opcode < 0 || in.Opcode >= len(mnemonics) {
+		return fmt.Sprintf("??? %d %d", in.Opcode, in.Operand)
+	}
+
+	operand := fmt.Sprint(in.Operand)
+	if name, ok := registerNames[in.Operand]; ok && takesCombo(in.Opcode) {
+		operand = strings.ToLower(name)
+	}
+	return mnemonics[in.Opcode] + " " + operand
+}
+
+// combo describes the value of a combo operand
+func combo(operand int) string {
+	if name, ok := registerNames[operand]; ok {
+		return name
+	}
+	if operand < 0 || operand > 3 {
+		return fmt.Sprintf("reserved(%d)", operand)
+	}
+	return fmt.Sprint(operand)
+}
+
+// Describe explains what the instruction does in terms of the registers,
+// e.g. "A = A >> 3" or "out B % 8".
+func (in Instruction) Describe() string {
+	switch in.Opcode {
+	case OpADV:
+		return "A = A >> " + combo(in.Operand)
+	case OpBXL:
+		return fmt.Sprintf("B = B ^ %d", in.Operand)
+	case OpBST:
+		return "B = " + combo(in.Operand) + " % 8"
+	case OpJNZ:
+		return fmt.Sprintf("if A != 0 jump %d", in.Operand)
+	case OpBXC:
+		return "B = B ^ C"
+	case OpOUT:
+		return "out " + combo(in.Operand) + " % 8"
+	case OpBDV:
+		return "B = A >> " + combo(in.Operand)
+	case OpCDV:
+		return "C = A >> " + combo(in.Operand)
+	default:
+		return "invalid opcode"
+	}
+}
+
+// Disassemble renders a program as a listing with one instruction per line,
+// giving its address, its assembler form and what it does.
+func Disassemble(code []int) string {
+	var sb strings.Builder
+	for _, in := range Decode(code) {
+		fmt.Fprintf(&sb, "%02d: %-6s ; %s\n", in.Addr, in, in.Describe())
+	}
+	return sb.String()
+}
@@ -0,0 +1,278 @@
+package day17
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// ErrNoSolution is returned when no value of register A makes a program
+// produce the wanted output.
+var ErrNoSolution = errors.New("no value of register A produces the output")
+
+// ErrSearchLimit is returned when the search for register A gave up before
+// it could tell whether a value produces the output.
+var ErrSearchLimit = errors.New("search for register A cut off")
+
+const (
+	// searchLimit bounds the values of A tried for programs whose shape the
+	// reverse solver does not understand
+	searchLimit = 1 << 20
+
+	// shiftLimit bounds the shifts tried for an adv whose operand is a
+	// register that may hold a larger value
+	shiftLimit = 12
+
+	// unbounded is the width of a register whose value has no known bound
+	unbounded = 64
+
+	// searchSteps bounds each run of the bounded search so that values of A
+	// that make a program loop forever are skipped
+	searchSteps = 10_000
+)
+
+// loopShape describes a program that is a single loop dropping the low bits
+// of A once per iteration and printing the same number of values on every
+// iteration
+type loopShape struct {
+	minShift, maxShift int  // Range of the bits of A dropped by the loop's adv
+	capped             bool // Whether the adv may drop more than maxShift bits
+	outs               int  // Values printed per iteration
+}
+
+// readsRegister reports whether an instruction reads the register that a
+// combo operand of 5 or 6 refers to, or that it reads directly.
+func readsRegister(in Instruction, register int) bool {
+	if takesCombo(in.Opcode) && in.Operand == register {
+		return true
+	}
+	switch in.Opcode {
+	case OpBXL:
+		return register == RegisterB
+	case OpBXC:
+		return register == RegisterB || register == RegisterC
+	}
+	return false
+}
+
+// writtenRegister returns the register an instruction writes, other than A
+func writtenRegister(in Instruction) (int, bool) {
+	switch in.Opcode {
+	case OpBXL, OpBST, OpBXC, OpBDV:
+		return RegisterB, true
+	case OpCDV:
+		return RegisterC, true
+	}
+	return 0, false
+}
+
+// analyzeLoop recognises programs that end in "jnz 0", contain no other
+// jump, shift A right in exactly one adv, print at least once and set B and
+// C afresh from A before reading them. Each iteration of such a program
+// prints values that depend only on the value of A at the start of the
+// iteration, which is what the reverse solver relies on.
+//
+// The adv may shift by a literal or by a register. For a register the
+// width of the values it can hold is followed through the loop body, so
+// that a register set by bst, or from such registers by bxl and bxc, is
+// known to shift by at most 7 bits.
+func analyzeLoop(code []int) (loopShape, bool) {
+	if len(code)%2 != 0 {
+		return loopShape{}, false
+	}
+
+	instructions := Decode(code)
+	if len(instructions) < 2 {
+		return loopShape{}, false
+	}
+	last := instructions[len(instructions)-1]
+	if last.Opcode != OpJNZ || last.Operand != 0 {
+		return loopShape{}, false
+	}
+
+	shape := loopShape{}
+	advs := 0
+	written := make(map[int]bool)
+	width := map[int]int{RegisterA: unbounded}
+	for _, in := range instructions[:len(instructions)-1] {
+		if in.Opcode < OpADV || in.Opcode > OpCDV || (takesCombo(in.Opcode) && in.Operand > RegisterC) {
+			return loopShape{}, false
+		}
+
+		for _, register := range []int{RegisterB, RegisterC} {
+			if readsRegister(in, register) && !written[register] {
+				return loopShape{}, false
+			}
+		}
+		switch in.Opcode {
+		case OpJNZ:
+			return loopShape{}, false
+		case OpADV:
+			if in.Operand == 0 {
+				return loopShape{}, false
+			}
+			shape.minShift, shape.maxShift = in.Operand, in.Operand
+			if in.Operand >= RegisterA {
+				// Dropping no bits would leave A unchanged and loop forever
+				shape.minShift, shape.maxShift = 1, shiftLimit
+				if w := width[in.Operand]; w < bits.Len(shiftLimit) {
+					shape.maxShift = 1<<w - 1
+				} else {
+					shape.capped = true
+				}
+			}
+			advs++
+		case OpOUT:
+			shape.outs++
+		case OpBST:
+			width[RegisterB] = 3
+		case OpBXL:
+			width[RegisterB] = max(width[RegisterB], 3)
+		case OpBXC:
+			width[RegisterB] = max(width[RegisterB], width[RegisterC])
+		case OpBDV:
+			width[RegisterB] = unbounded
+		case OpCDV:
+			width[RegisterC] = unbounded
+		}
+
+		if register, ok := writtenRegister(in); ok {
+			written[register] = true
+		}
+	}
+	return shape, advs == 1 && shape.outs > 0 && shape.minShift <= shape.maxShift
+}
+
+// runIteration executes the loop body once with the given value of A and
+// returns the printed values and the value of A left for the next iteration
+func runIteration(a, b, c int, code []int) ([]int, int, error) {
+	computer := NewComputer(a, b, c, code)
+	for computer.IP < len(code)-2 {
+		if _, err := computer.executeInstruction(); err != nil {
+			return nil, 0, err
+		}
+	}
+	return computer.Output, computer.A, nil
+}
+
+// solveLoop rebuilds A from its most significant bits down. The last
+// values printed depend only on the top bits, the ones before them on those
+// and the bits the iteration before dropped, and so on, so the search
+// extends the bits fixed so far one iteration at a time and backtracks when
+// no extension prints the right values. Extensions are tried smallest
+// first, and as every iteration drops at least one bit a partial value
+// that cannot lead below the best A found so far is abandoned, so the
+// value returned is the smallest. It also reports whether shifts beyond
+// the shape's maxShift could have led to a smaller A, or to one at all.
+func solveLoop(shape loopShape, b, c int, code, target []int) (int, bool, bool) {
+	best, skipped := -1, -1
+	var search func(a, i int)
+	search = func(a, i int) {
+		if i < 0 {
+			if best < 0 || a < best {
+				best = a
+			}
+			return
+		}
+		want := target[i*shape.outs : (i+1)*shape.outs]
+
+		for shift := shape.minShift; shift <= shape.maxShift; shift++ {
+			if bits.Len(uint(a))+shift > 62 {
+				// Larger values of A do not fit in an int
+				return
+			}
+			first := a << shift
+			if a == 0 && shift > shape.minShift {
+				// Smaller candidates were tried with the shift before
+				first = 1 << (shift - 1)
+			}
+
+			for candidate := first; candidate < (a+1)<<shift; candidate++ {
+				if candidate == 0 && i > 0 {
+					// A must be non-zero for the loop to reach this iteration
+					continue
+				}
+				if best >= 0 && candidate > best>>i {
+					return
+				}
+
+				out, next, err := runIteration(candidate, b, c, code)
+				if err != nil || next != a || !slices.Equal(out, want) {
+					continue
+				}
+				search(candidate, i-1)
+			}
+		}
+
+		if shape.capped {
+			// The smallest A that a larger shift could have led to, if it
+			// fits in an int
+			exponent := bits.Len(uint(a)) + shape.maxShift + i
+			least := a << (shape.maxShift + 1 + i)
+			if a == 0 {
+				exponent, least = shape.maxShift+i, 1<<(shape.maxShift+i)
+			}
+			if exponent <= 62 && (skipped < 0 || least < skipped) {
+				skipped = least
+			}
+		}
+	}
+	search(0, len(target)/shape.outs-1)
+
+	cutOff := skipped >= 0 && (best < 0 || skipped <= best)
+	return best, best >= 0, cutOff
+}
+
+// runBounded runs a program for at most the given number of steps and
+// reports whether it halted cleanly in time
+func runBounded(a, b, c int, code []int, steps int) ([]int, bool) {
+	out, err := NewComputer(a, b, c, code).Run(steps)
+	return out, err == nil
+}
+
+// FindMinimalA returns the smallest value of register A that makes the
+// program print exactly the target output. Programs that never print and
+// programs that are a simple loop, like the puzzle inputs, are solved
+// exactly and ErrNoSolution means no such A exists. Any other program is
+// searched up to a fixed bound, as is an adv that may shift by more bits
+// than the solver tries, and ErrSearchLimit means the search gave up
+// without an answer.
+func FindMinimalA(b, c int, code, target []int) (int, error) {
+	if !slices.Contains(code, OpOUT) {
+		// No instruction that prints can ever run, wherever a jump lands
+		if len(target) > 0 {
+			return 0, fmt.Errorf("%w: the program never prints", ErrNoSolution)
+		}
+	} else if shape, ok := analyzeLoop(code); ok {
+		if len(target) == 0 || len(target)%shape.outs != 0 {
+			// The loop prints the same number of values on every
+			// iteration and runs at least once
+			return 0, fmt.Errorf("%w: the program prints %d values per iteration", ErrNoSolution, shape.outs)
+		}
+
+		a, found, cutOff := solveLoop(shape, b, c, code, target)
+		if cutOff {
+			return 0, fmt.Errorf("%w: shifts above %d bits were not tried", ErrSearchLimit, shape.maxShift)
+		}
+		if !found {
+			return 0, ErrNoSolution
+		}
+		if out, ok := runBounded(a, b, c, code, searchSteps*len(target)); !ok || !slices.Equal(out, target) {
+			return 0, fmt.Errorf("A=%d does not reproduce the output when run", a)
+		}
+		return a, nil
+	}
+
+	for a := 0; a < searchLimit; a++ {
+		if out, ok := runBounded(a, b, c, code, searchSteps); ok && slices.Equal(out, target) {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: no A below %d produces the output", ErrSearchLimit, searchLimit)
+}
+
+// findSelfReplicatingValue finds the lowest value of A that makes the program output itself
+func findSelfReplicatingValue(b, c int, code []int) (int, error) {
+	return FindMinimalA(b, c, code, code)
+}
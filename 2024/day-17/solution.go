@@ -1,13 +1,15 @@
-package main
+package day17
 
 import (
 	"bufio"
+	_ "embed"
+	"errors"
 	"fmt"
 	"io"
-	"os"
-	"slices"
 	"strconv"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 // Register names for the 3-bit computer
@@ -35,6 +37,7 @@ type Computer struct {
 	Code    []int // Program
 	IP      int   // Instruction Pointer
 	Output  []int // Program output
+	Steps   int   // Instructions executed so far
 }
 
 // NewComputer creates a new computer with initial register values and program
@@ -49,31 +52,80 @@ func NewComputer(a, b, c int, code []int) *Computer {
 	}
 }
 
+// DefaultMaxSteps is a step budget far beyond what any puzzle program needs,
+// used to stop programs that never halt
+const DefaultMaxSteps = 10_000_000
+
+var (
+	// ErrReservedOperand is returned for a combo operand of 7
+	ErrReservedOperand = errors.New("reserved combo operand 7")
+
+	// ErrInvalidOpcode is returned for opcodes outside 0 to 7
+	ErrInvalidOpcode = errors.New("invalid opcode")
+
+	// ErrNegativeOperand is returned when adv, bdv or cdv would divide by
+	// 2 to a negative power, which only a negative register can cause
+	ErrNegativeOperand = errors.New("negative division exponent")
+
+	// ErrNonTerminating is returned when a program is still running once
+	// its step budget is used up
+	ErrNonTerminating = errors.New("program did not halt within its step budget")
+)
+
+// ExecError reports why a program stopped before running to completion
+type ExecError struct {
+	IP  int   // Address of the instruction that failed
+	Err error // ErrReservedOperand, ErrInvalidOpcode, ErrNegativeOperand or ErrNonTerminating
+}
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("at address %d: %v", e.IP, e.Err)
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
 // getComboValue returns the value of a combo operand based on the rules
-func (c *Computer) getComboValue(operand int) int {
+func (c *Computer) getComboValue(operand int) (int, error) {
 	switch operand {
 	case 0, 1, 2, 3:
-		return operand
+		return operand, nil
 	case RegisterA:
-		return c.A
+		return c.A, nil
 	case RegisterB:
-		return c.B
+		return c.B, nil
 	case RegisterC:
-		return c.C
+		return c.C, nil
 	default:
-		return 0 // Handle reserved value 7
+		return 0, ErrReservedOperand
 	}
 }
 
 // executeInstruction executes a single instruction and returns whether to continue execution
-func (c *Computer) executeInstruction() bool {
-	if c.IP >= len(c.Code)-1 { // Check if we can read both opcode and operand
-		return false
+func (c *Computer) executeInstruction() (bool, error) {
+	if c.IP < 0 || c.IP >= len(c.Code)-1 { // Check if we can read both opcode and operand
+		return false, nil
 	}
 
 	opcode := c.Code[c.IP]
 	operand := c.Code[c.IP+1]
-	comboValue := c.getComboValue(operand)
+	if opcode < OpADV || opcode > OpCDV {
+		return false, &ExecError{IP: c.IP, Err: ErrInvalidOpcode}
+	}
+
+	var comboValue int
+	if takesCombo(opcode) {
+		value, err := c.getComboValue(operand)
+		if err != nil {
+			return false, &ExecError{IP: c.IP, Err: err}
+		}
+		comboValue = value
+	}
+	if divides := opcode == OpADV || opcode == OpBDV || opcode == OpCDV; divides && comboValue < 0 {
+		return false, &ExecError{IP: c.IP, Err: ErrNegativeOperand}
+	}
+	c.Steps++
 
 	switch opcode {
 	case OpADV:
@@ -85,7 +137,7 @@ func (c *Computer) executeInstruction() bool {
 	case OpJNZ:
 		if c.A != 0 {
 			c.IP = operand
-			return true
+			return true, nil
 		}
 	case OpBXC:
 		c.B ^= c.C
@@ -98,14 +150,46 @@ func (c *Computer) executeInstruction() bool {
 	}
 
 	c.IP += 2
-	return true
+	return true, nil
+}
+
+// machineState is everything that decides what a program does next
+type machineState struct {
+	a, b, c, ip int
 }
 
-// Run executes the program until completion and returns the output
-func (c *Computer) Run() []int {
-	for c.executeInstruction() {
+// Run executes the program until completion and returns the output. It
+// gives up with ErrNonTerminating once maxSteps instructions have run, or
+// as soon as the computer returns to an earlier state, because a program
+// that does so repeats itself forever.
+func (c *Computer) Run(maxSteps int) ([]int, error) {
+	// Brent's cycle detection: compare against a state saved at steps that
+	// are powers of two, which finds any cycle within twice its length
+	saved, power := c.state(), 1
+	for steps := 1; ; steps++ {
+		if c.Steps >= maxSteps && c.IP >= 0 && c.IP < len(c.Code)-1 {
+			return c.Output, &ExecError{IP: c.IP, Err: ErrNonTerminating}
+		}
+		running, err := c.executeInstruction()
+		if err != nil {
+			return c.Output, err
+		}
+		if !running {
+			return c.Output, nil
+		}
+
+		if c.state() == saved {
+			return c.Output, &ExecError{IP: c.IP, Err: ErrNonTerminating}
+		}
+		if steps == power {
+			saved, power = c.state(), power*2
+		}
 	}
-	return c.Output
+}
+
+// state captures the registers and instruction pointer
+func (c *Computer) state() machineState {
+	return machineState{c.A, c.B, c.C, c.IP}
 }
 
 // ProgramInput represents the parsed input file
@@ -124,9 +208,13 @@ func parseInput(r io.Reader) (*ProgramInput, error) {
 		if !scanner.Scan() {
 			return nil, fmt.Errorf("failed to read register %d", i)
 		}
-		val, err := strconv.Atoi(strings.Fields(scanner.Text())[2])
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected \"Register X: <value>\", got %q", i+1, scanner.Text())
+		}
+		val, err := strconv.Atoi(fields[2])
 		if err != nil {
-			return nil, fmt.Errorf("invalid register value: %v", err)
+			return nil, fmt.Errorf("line %d: invalid register value: %v", i+1, err)
 		}
 		registers[i] = val
 	}
@@ -134,17 +222,13 @@ func parseInput(r io.Reader) (*ProgramInput, error) {
 	// Skip empty line
 	scanner.Scan()
 
-	// Parse program code
+	// Parse program code, which is on the fifth line
 	if !scanner.Scan() {
 		return nil, fmt.Errorf("failed to read program code")
 	}
-	var code []int
-	for _, s := range strings.Split(strings.Fields(scanner.Text())[1], ",") {
-		n, err := strconv.Atoi(s)
-		if err != nil {
-			return nil, fmt.Errorf("invalid program code: %v", err)
-		}
-		code = append(code, n)
+	code, err := ParseProgram(scanner.Text())
+	if err != nil {
+		return nil, fmt.Errorf("line 5: %w", err)
 	}
 
 	return &ProgramInput{
@@ -155,47 +239,53 @@ func parseInput(r io.Reader) (*ProgramInput, error) {
 	}, scanner.Err()
 }
 
-// findSelfReplicatingValue finds the lowest value of A that makes the program output itself
-func findSelfReplicatingValue(b, c int, code []int) int {
-	a := 0
-	for i := len(code) - 1; i >= 0; i-- {
-		// make room for 3 bits by shifting to the left
-		a <<= 3
-
-		// Incrementally try values until we find the right one
-		for {
-			comp := NewComputer(a, b, c, code)
-			if slices.Equal(comp.Run(), code[i:]) {
-				break
-			}
-			a++
-		}
-	}
-	return a
+//go:embed input.txt
+var input string
+
+// Trace receives a trace of every instruction executed by Part1 when set
+var Trace io.Writer
+
+func init() {
+	aoc.Register(2024, 17, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 17, input)
 }
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		os.Exit(1)
-	}
-	defer file.Close()
+// Solver solves day 17 using the initial registers and program
+type Solver struct {
+	input *ProgramInput
+}
 
-	input, err := parseInput(file)
+// Parse reads the register values and the program
+func (s *Solver) Parse(r io.Reader) error {
+	input, err := parseInput(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing input: %v\n", err)
-		os.Exit(1)
+		return err
 	}
 
-	// Part 1: Run the program with initial values
-	computer := NewComputer(input.A, input.B, input.C, input.Code)
-	output := computer.Run()
-	fmt.Printf("Part 1: %s\n", strings.Join(formatOutput(output), ","))
+	s.input = input
+	return nil
+}
 
-	// Part 2: Find the self-replicating value
-	result := findSelfReplicatingValue(input.B, input.C, input.Code)
-	fmt.Printf("Part 2: %d\n", result)
+// Part1 returns the comma separated output of the program
+func (s *Solver) Part1() (aoc.Answer, error) {
+	computer := NewComputer(s.input.A, s.input.B, s.input.C, s.input.Code)
+	debugger := NewDebugger(computer)
+	debugger.Trace = Trace
+	debugger.MaxSteps = DefaultMaxSteps
+	if _, err := debugger.Continue(); err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Text(strings.Join(formatOutput(computer.Output), ",")), nil
+}
+
+// Part2 returns the lowest value of register A that makes the program
+// output a copy of itself
+func (s *Solver) Part2() (aoc.Answer, error) {
+	a, err := findSelfReplicatingValue(s.input.B, s.input.C, s.input.Code)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(a), nil
 }
 
 // formatOutput converts a slice of ints to a slice of strings
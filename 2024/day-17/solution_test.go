@@ -1,6 +1,7 @@
-package main
+package day17
 
 import (
+	"errors"
 	"slices"
 	"strings"
 	"testing"
@@ -68,7 +69,10 @@ func TestComputer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			computer := NewComputer(tt.a, tt.b, tt.c, tt.program)
-			output := computer.Run()
+			output, err := computer.Run(DefaultMaxSteps)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
 
 			// Convert output to string for easier comparison in error messages
 			gotStr := strings.Join(formatOutput(output), ",")
@@ -89,16 +93,309 @@ func TestComputer(t *testing.T) {
 		program := []int{0, 3, 5, 4, 3, 0}
 		expected := 117440
 
-		result := findSelfReplicatingValue(0, 0, program)
+		result, err := findSelfReplicatingValue(0, 0, program)
+		if err != nil {
+			t.Fatalf("findSelfReplicatingValue() error = %v", err)
+		}
 		if result != expected {
 			t.Errorf("findSelfReplicatingValue() = %d, want %d", result, expected)
 		}
 
 		// Verify the output matches the program
 		computer := NewComputer(result, 0, 0, program)
-		output := computer.Run()
+		output, err := computer.Run(DefaultMaxSteps)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
 		if !slices.Equal(output, program) {
 			t.Errorf("Output %v does not match program %v with A=%d", output, program, result)
 		}
 	})
 }
+
+func TestDisassemble(t *testing.T) {
+	program := []int{0, 1, 5, 4, 3, 0}
+	want := "00: adv 1  ; A = A >> 1\n" +
+		"02: out a  ; out A % 8\n" +
+		"04: jnz 0  ; if A != 0 jump 0\n"
+
+	if got := Disassemble(program); got != want {
+		t.Errorf("Disassemble() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInstructionString(t *testing.T) {
+	tests := []struct {
+		in       Instruction
+		asm      string
+		describe string
+	}{
+		{Instruction{Opcode: OpADV, Operand: 3}, "adv 3", "A = A >> 3"},
+		{Instruction{Opcode: OpBXL, Operand: 5}, "bxl 5", "B = B ^ 5"},
+		{Instruction{Opcode: OpBST, Operand: RegisterA}, "bst a", "B = A % 8"},
+		{Instruction{Opcode: OpOUT, Operand: RegisterB}, "out b", "out B % 8"},
+		{Instruction{Opcode: OpCDV, Operand: RegisterB}, "cdv b", "C = A >> B"},
+		{Instruction{Opcode: OpBXC, Operand: 2}, "bxc 2", "B = B ^ C"},
+		{Instruction{Opcode: OpBDV, Operand: 7}, "bdv 7", "B = A >> reserved(7)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.asm {
+			t.Errorf("String() = %q, want %q", got, tt.asm)
+		}
+		if got := tt.in.Describe(); got != tt.describe {
+			t.Errorf("Describe() = %q, want %q", got, tt.describe)
+		}
+	}
+}
+
+func TestDebugger(t *testing.T) {
+	program := []int{0, 1, 5, 4, 3, 0}
+
+	t.Run("breakpoint", func(t *testing.T) {
+		debugger := NewDebugger(NewComputer(2024, 0, 0, program))
+		debugger.Break(4)
+
+		var stops []int
+		for {
+			reason, err := debugger.Continue()
+			if err != nil {
+				t.Fatalf("Continue() error = %v", err)
+			}
+			if reason != Breakpoint {
+				break
+			}
+			stops = append(stops, len(debugger.Computer.Output))
+		}
+		// The loop reaches the jump once per output before halting
+		if len(stops) != 11 || stops[0] != 1 {
+			t.Errorf("stopped at breakpoint with outputs %v, want 11 stops starting at 1", stops)
+		}
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		debugger := NewDebugger(NewComputer(10, 0, 0, []int{5, 0, 2, 4, 5, 5}))
+		if err := debugger.Watch("B"); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, _ := debugger.Continue(); got != Watchpoint {
+			t.Fatalf("Continue() = %v, want watchpoint", got)
+		}
+		if debugger.Computer.IP != 4 || debugger.Computer.B != 2 {
+			t.Errorf("stopped at IP %d with B=%d, want IP 4 with B=2", debugger.Computer.IP, debugger.Computer.B)
+		}
+		if got, _ := debugger.Continue(); got != Halted {
+			t.Errorf("Continue() = %v, want halted", got)
+		}
+		if err := debugger.Watch("D"); err == nil {
+			t.Error("Watch(\"D\") did not return an error")
+		}
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		var trace strings.Builder
+		debugger := NewDebugger(NewComputer(10, 0, 0, []int{5, 0, 5, 4}))
+		debugger.Trace = &trace
+		if _, err := debugger.Continue(); err != nil {
+			t.Fatalf("Continue() error = %v", err)
+		}
+
+		want := "00: out 0   A=10 B=0 C=0  out 0\n" +
+			"02: out a   A=10 B=0 C=0  out 2\n"
+		if trace.String() != want {
+			t.Errorf("trace =\n%s\nwant\n%s", trace.String(), want)
+		}
+	})
+}
+
+func TestFindMinimalA(t *testing.T) {
+	puzzle := []int{2, 4, 1, 5, 7, 5, 1, 6, 0, 3, 4, 2, 5, 5, 3, 0}
+
+	tests := []struct {
+		name    string
+		code    []int
+		target  []int
+		want    int // Checked instead of trying every smaller A when set
+		wantErr error
+	}{
+		{name: "example shifting one bit", code: []int{0, 1, 5, 4, 3, 0}, target: []int{4, 6, 3, 5, 6, 3, 5, 2, 1, 0}},
+		{name: "puzzle loop", code: puzzle, target: []int{3, 0}},
+		{name: "puzzle loop with a longer output", code: puzzle, target: []int{5, 5, 3, 0}},
+		{name: "straight-line program", code: []int{2, 4, 1, 3, 5, 5}, target: []int{6}},
+		{name: "loop that can never print 7 once", code: []int{0, 3, 5, 4, 3, 0}, target: []int{7}, wantErr: ErrNoSolution},
+		{name: "loop that always prints", code: []int{0, 3, 5, 4, 3, 0}, target: []int{}, wantErr: ErrNoSolution},
+		{name: "loop printing twice per iteration", code: []int{0, 3, 5, 4, 5, 4, 3, 0}, target: []int{2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 0, 0}, want: 2054352},
+		{name: "loop printing twice cannot print once", code: []int{0, 3, 5, 4, 5, 4, 3, 0}, target: []int{2, 2, 3}, wantErr: ErrNoSolution},
+		{name: "loop shifting by a register", code: []int{2, 4, 1, 1, 0, 5, 5, 5, 3, 0}, target: []int{3, 3, 3, 1, 5}},
+		{name: "loop shifting by A", code: []int{5, 4, 0, 4, 3, 0}, target: []int{5}},
+		{name: "program beyond the search limit", code: []int{5, 4, 3, 0}, target: []int{1}, wantErr: ErrSearchLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindMinimalA(0, 0, tt.code, tt.target)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FindMinimalA() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr == ErrSearchLimit && errors.Is(err, ErrNoSolution) {
+					t.Fatalf("FindMinimalA() error = %v claims there is no solution", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindMinimalA() error = %v", err)
+			}
+
+			if tt.want != 0 {
+				if out, ok := runBounded(got, 0, 0, tt.code, searchSteps); got != tt.want || !ok || !slices.Equal(out, tt.target) {
+					t.Fatalf("FindMinimalA() = %d printing %v, want %d", got, out, tt.want)
+				}
+				return
+			}
+
+			// The answer must print the target and nothing smaller may
+			for a := 0; a <= got; a++ {
+				out, ok := runBounded(a, 0, 0, tt.code, searchSteps)
+				if matches := ok && slices.Equal(out, tt.target); matches != (a == got) {
+					t.Fatalf("FindMinimalA() = %d, but A=%d prints %v", got, a, out)
+				}
+			}
+		})
+	}
+}
+
+func TestAssemble(t *testing.T) {
+	src := `; the self-replicating example
+loop:
+	adv 3        # drop the lowest three bits
+	out a
+	jnz loop
+`
+	code, err := Assemble(src)
+	if err != nil {
+		t.Fatalf("Assemble() error = %v", err)
+	}
+	if want := []int{0, 3, 5, 4, 3, 0}; !slices.Equal(code, want) {
+		t.Errorf("Assemble() = %v, want %v", code, want)
+	}
+	if got, want := FormatProgram(code), "Program: 0,3,5,4,3,0"; got != want {
+		t.Errorf("FormatProgram() = %q, want %q", got, want)
+	}
+}
+
+func TestAssembleRoundTrip(t *testing.T) {
+	programs := [][]int{
+		{2, 4, 1, 5, 7, 5, 1, 6, 0, 3, 4, 2, 5, 5, 3, 0},
+		{0, 1, 5, 4, 3, 0},
+		{2, 6},
+		{4, 0},
+	}
+
+	for _, program := range programs {
+		code, err := Assemble(Disassemble(program))
+		if err != nil {
+			t.Fatalf("Assemble(Disassemble(%v)) error = %v", program, err)
+		}
+		if !slices.Equal(code, program) {
+			t.Errorf("Assemble(Disassemble(%v)) = %v", program, code)
+		}
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		line int
+	}{
+		{"reserved combo operand", "bst a\nout 7\n", 2},
+		{"operand too large", "bxl 8", 1},
+		{"unknown label", "adv 3\n\njnz start", 3},
+		{"label beyond 3 bits", "adv 1\nadv 1\nadv 1\nadv 1\nend: out a\njnz end", 6},
+		{"unknown instruction", "mul 3", 1},
+		{"register as a literal", "bxl a", 1},
+		{"missing operand", "adv", 1},
+		{"wrong address", "00: adv 3\n04: out a", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Assemble(tt.src)
+			var asmErr *AsmError
+			if !errors.As(err, &asmErr) {
+				t.Fatalf("Assemble() error = %v, want an AsmError", err)
+			}
+			if asmErr.Line != tt.line {
+				t.Errorf("Assemble() error on line %d, want line %d: %v", asmErr.Line, tt.line, err)
+			}
+		})
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		program string
+		want    string
+	}{
+		{"odd length", "Program: 0,3,5", "line 5: program has an odd number of values (3)"},
+		{"reserved combo operand", "Program: 0,3,5,7", "line 5: out at address 2 uses the reserved combo operand 7"},
+		{"value out of range", "Program: 0,9", "line 5: invalid program value \"9\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "Register A: 1\nRegister B: 0\nRegister C: 0\n\n" + tt.program + "\n"
+			_, err := parseInput(strings.NewReader(input))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("parseInput() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    int
+		program []int
+		want    error
+		wantIP  int
+	}{
+		{"reserved combo operand", 1, 0, []int{1, 7, 5, 7}, ErrReservedOperand, 2},
+		{"invalid opcode", 1, 0, []int{5, 4, 8, 0}, ErrInvalidOpcode, 2},
+		{"never halts", 1, 0, []int{1, 1, 3, 0}, ErrNonTerminating, 0},
+		{"step budget used up", 1 << 40, 0, []int{0, 1, 3, 0}, ErrNonTerminating, 0},
+		{"negative register as shift", 1, -1, []int{0, 5, 5, 4, 3, 0}, ErrNegativeOperand, 0},
+		{"negative A divides itself", -8, 0, []int{5, 4, 7, 4}, ErrNegativeOperand, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewComputer(tt.a, tt.b, 0, tt.program).Run(10)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Run() error = %v, want %v", err, tt.want)
+			}
+			var execErr *ExecError
+			if !errors.As(err, &execErr) || execErr.IP != tt.wantIP {
+				t.Errorf("Run() error = %v, want it at address %d", err, tt.wantIP)
+			}
+		})
+	}
+
+	t.Run("debugger step budget", func(t *testing.T) {
+		debugger := NewDebugger(NewComputer(1, 0, 0, []int{1, 1, 3, 0}))
+		debugger.MaxSteps = 100
+		if _, err := debugger.Continue(); !errors.Is(err, ErrNonTerminating) {
+			t.Errorf("Continue() error = %v, want %v", err, ErrNonTerminating)
+		}
+	})
+
+	t.Run("self-replication of a program that never halts", func(t *testing.T) {
+		if _, err := findSelfReplicatingValue(0, 0, []int{1, 1, 3, 0}); !errors.Is(err, ErrNoSolution) {
+			t.Errorf("findSelfReplicatingValue() error = %v, want %v", err, ErrNoSolution)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package day18
+
+import "aoc2024/grid"
+
+// FirstBlockingByte returns the first byte falling onto the grid after
+// which the exit can no longer be reached, and its index in positions. It
+// returns -1 as the index if the exit stays reachable after every byte has
+// fallen. Cells already corrupted stay so, and the grid is left unchanged.
+//
+// Rather than searching again after every byte, it lets all of them fall
+// and then clears them in reverse order, joining each cleared cell to its
+// open neighbours. The first byte to be cleared that connects the start to
+// the exit is the one that cut them off.
+func (g *MemoryGrid) FirstBlockingByte(positions []grid.Point) (grid.Point, int) {
+	memory := &MemoryGrid{corrupted: g.corrupted.Clone(), start: g.start, exit: g.exit}
+	fallen := grid.New[int](memory.corrupted.Width(), memory.corrupted.Height())
+	for pos := range fallen.Points() {
+		fallen.Set(pos, -1)
+	}
+	for i, pos := range positions {
+		if fallen.InBounds(pos) && !memory.IsCorrupted(pos) {
+			fallen.Set(pos, i)
+			memory.AddCorruption(pos)
+		}
+	}
+
+	cells := newDisjointSet(fallen.Width() * fallen.Height())
+	id := func(p grid.Point) int { return p.Y*fallen.Width() + p.X }
+	connected := func() bool {
+		return !memory.IsCorrupted(memory.start) && !memory.IsCorrupted(memory.exit) &&
+			cells.find(id(memory.start)) == cells.find(id(memory.exit))
+	}
+	open := func(pos grid.Point) {
+		memory.corrupted.Set(pos, false)
+		for next := range memory.openNeighbours(pos) {
+			cells.union(id(pos), id(next))
+		}
+	}
+
+	for pos := range fallen.Points() {
+		if !memory.IsCorrupted(pos) {
+			open(pos)
+		}
+	}
+	if connected() {
+		return grid.Point{}, -1
+	}
+
+	for i := len(positions) - 1; i >= 0; i-- {
+		pos := positions[i]
+		if !fallen.InBounds(pos) || fallen.At(pos) != i {
+			continue
+		}
+		open(pos)
+		if connected() {
+			return pos, i
+		}
+	}
+	return grid.Point{}, -1
+}
+
+// disjointSet is a union-find structure over the numbers 0 to n-1
+type disjointSet struct {
+	parent []int
+	size   []int
+}
+
+// newDisjointSet returns n sets of one number each
+func newDisjointSet(n int) *disjointSet {
+	d := &disjointSet{parent: make([]int, n), size: make([]int, n)}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
+
+// find returns the representative of the set holding x
+func (d *disjointSet) find(x int) int {
+	for d.parent[x] != x {
+		d.parent[x] = d.parent[d.parent[x]]
+		x = d.parent[x]
+	}
+	return x
+}
+
+// union merges the sets holding x and y
+func (d *disjointSet) union(x, y int) {
+	x, y = d.find(x), d.find(y)
+	if x == y {
+		return
+	}
+	if d.size[x] < d.size[y] {
+		x, y = y, x
+	}
+	d.parent[y] = x
+	d.size[x] += d.size[y]
+}
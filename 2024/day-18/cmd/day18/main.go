@@ -0,0 +1,42 @@
+// Command day18 solves Advent of Code 2024 day 18. It reads the file given
+// with -input, or standard input for "-input -", and otherwise uses the
+// puzzle input embedded in the binary. The other flags describe the memory
+// space: its size, where the walk starts and ends, and how many bytes have
+// fallen before the walk in part one. The exit defaults to the corner
+// opposite the top left. With -timed part one instead walks while the
+// bytes fall, one per step, and lists the steps on standard error.
+package main
+
+import (
+	"flag"
+	"os"
+
+	"aoc2024/aoc"
+	day18 "aoc2024/day-18"
+	"aoc2024/grid"
+)
+
+func main() {
+	layout := day18.DefaultLayout
+	flag.IntVar(&layout.Width, "width", layout.Width, "width of the memory space")
+	flag.IntVar(&layout.Height, "height", layout.Height, "height of the memory space")
+	flag.IntVar(&layout.Fallen, "fallen", layout.Fallen, "number of bytes that have fallen before the walk in part one")
+	start := flag.String("start", layout.Start.String(), "position the walk starts at, as x,y")
+	timed := flag.Bool("timed", false, "in part one, walk while the bytes fall one per step and list the steps on standard error")
+	exit := flag.String("exit", "", "position of the exit, as x,y (default the bottom right corner)")
+
+	aoc.MainWith(2024, 18, func() (aoc.Solver, error) {
+		var err error
+		if layout.Start, err = day18.ParsePoint(*start); err != nil {
+			return nil, err
+		}
+		layout.Exit = grid.Point{X: layout.Width - 1, Y: layout.Height - 1}
+		if *exit != "" {
+			if layout.Exit, err = day18.ParsePoint(*exit); err != nil {
+				return nil, err
+			}
+		}
+
+		return day18.NewSolver(day18.Options{Layout: layout, Timed: *timed, TimedLog: os.Stderr})
+	})
+}
@@ -1,117 +1,142 @@
-package main
+package day18
 
 import (
 	"bufio"
+	_ "embed"
+	"errors"
 	"fmt"
 	"io"
-	"os"
+	"iter"
 	"strconv"
 	"strings"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
+	"aoc2024/search"
 )
 
-var GridSize = 70
+// Layout describes a memory space: its size, where the walk starts and ends
+// and how many bytes have fallen before the walk in part one
+type Layout struct {
+	Width, Height int
+	Start, Exit   grid.Point
+	Fallen        int
+}
 
-// Position represents a coordinate in the memory grid
-type Position struct {
-	x, y int
+// DefaultLayout is the memory space of the puzzle input
+var DefaultLayout = Layout{
+	Width:  71,
+	Height: 71,
+	Start:  grid.Point{X: 0, Y: 0},
+	Exit:   grid.Point{X: 70, Y: 70},
+	Fallen: 1024,
 }
 
-// Grid constants
-var (
-	startPos = Position{0, 0}
-	exitPos  = Position{GridSize, GridSize}
-	// Possible movements: right, down, left, up
-	directions = []Position{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
-)
+// ExampleLayout is the smaller memory space of the puzzle's example
+var ExampleLayout = Layout{
+	Width:  7,
+	Height: 7,
+	Start:  grid.Point{X: 0, Y: 0},
+	Exit:   grid.Point{X: 6, Y: 6},
+	Fallen: 12,
+}
+
+// Options configure a Solver
+type Options struct {
+	// Layout is the memory space the bytes fall into
+	Layout Layout
+
+	// Timed makes Part1 walk while the bytes fall, one per step, instead
+	// of after the first ones have fallen
+	Timed bool
+
+	// TimedLog receives the steps of the walk when Timed is set, if not nil
+	TimedLog io.Writer
+}
+
+// DefaultOptions solve the puzzle as set
+var DefaultOptions = Options{Layout: DefaultLayout}
+
+// Validate checks that the memory space is not empty, that the start and
+// exit lie inside it and that the number of fallen bytes is not negative
+func (l Layout) Validate() error {
+	if l.Width <= 0 || l.Height <= 0 {
+		return fmt.Errorf("memory space of %dx%d is empty", l.Width, l.Height)
+	}
+	bounds := grid.New[bool](l.Width, l.Height)
+	if !bounds.InBounds(l.Start) {
+		return fmt.Errorf("start %v is outside the %dx%d memory space", l.Start, l.Width, l.Height)
+	}
+	if !bounds.InBounds(l.Exit) {
+		return fmt.Errorf("exit %v is outside the %dx%d memory space", l.Exit, l.Width, l.Height)
+	}
+	if l.Fallen < 0 {
+		return fmt.Errorf("negative number of fallen bytes %d", l.Fallen)
+	}
+	return nil
+}
 
 // represents the state of corrupted memory positions
 type MemoryGrid struct {
-	corrupted map[Position]struct{}
+	corrupted   *grid.Grid[bool]
+	start, exit grid.Point
 }
 
-// creates a new memory grid
-func NewMemoryGrid() *MemoryGrid {
+// creates a new memory grid with the size and endpoints of a layout
+func NewMemoryGrid(layout Layout) *MemoryGrid {
 	return &MemoryGrid{
-		corrupted: make(map[Position]struct{}),
+		corrupted: grid.New[bool](layout.Width, layout.Height),
+		start:     layout.Start,
+		exit:      layout.Exit,
 	}
 }
 
-// marks a position as corrupted
-func (g *MemoryGrid) AddCorruption(pos Position) {
-	g.corrupted[pos] = struct{}{}
+// marks a position as corrupted. Bytes outside the memory space never land,
+// as in FirstBlockingByte and EarliestArrival.
+func (g *MemoryGrid) AddCorruption(pos grid.Point) {
+	if g.corrupted.InBounds(pos) {
+		g.corrupted.Set(pos, true)
+	}
 }
 
 // checks if a position is corrupted... no really, it does
-func (g *MemoryGrid) IsCorrupted(pos Position) bool {
-	_, exists := g.corrupted[pos]
-	return exists
+func (g *MemoryGrid) IsCorrupted(pos grid.Point) bool {
+	corrupted, _ := g.corrupted.Get(pos)
+	return corrupted
 }
 
-// checks if a position is within grid bounds
-func IsValidPosition(pos Position) bool {
-	return pos.x >= 0 && pos.x <= GridSize &&
-		pos.y >= 0 && pos.y <= GridSize
+// finds the shortest path from start to exit using BFS. The path lists the
+// positions stepped onto, so its length is the number of steps taken.
+func (g *MemoryGrid) FindShortestPath() []grid.Point {
+	path, found := search.BFS(g.start, g.openNeighbours, func(p grid.Point) bool { return p == g.exit })
+	if !found {
+		return nil
+	}
+	return path.States[1:]
 }
 
-// finds the shortest path from start to exit using BFS
-func (g *MemoryGrid) FindShortestPath() []Position {
-	queue := []Position{startPos}
-	visited := map[Position]struct{}{startPos: {}}
-	parent := make(map[Position]Position)
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		if current == exitPos {
-			return reconstructPath(parent)
-		}
-
-		for _, dir := range directions {
-			next := Position{
-				x: current.x + dir.x,
-				y: current.y + dir.y,
-			}
-
-			if !IsValidPosition(next) ||
-				g.IsCorrupted(next) ||
-				isVisited(visited, next) {
-				continue
+// yields the neighbouring positions that are not corrupted
+func (g *MemoryGrid) openNeighbours(pos grid.Point) iter.Seq[grid.Point] {
+	return func(yield func(grid.Point) bool) {
+		for next := range g.corrupted.Neighbours4(pos) {
+			if !g.IsCorrupted(next) && !yield(next) {
+				return
 			}
-
-			visited[next] = struct{}{}
-			parent[next] = current
-			queue = append(queue, next)
 		}
 	}
-
-	return nil
-}
-
-// builds the path from exit to start using parent mapping
-func reconstructPath(parent map[Position]Position) []Position {
-	var path []Position
-	for pos := exitPos; pos != startPos; pos = parent[pos] {
-		path = append(path, pos)
-	}
-	return path
-}
-
-func isVisited(visited map[Position]struct{}, pos Position) bool {
-	_, exists := visited[pos]
-	return exists
 }
 
 // reads corrupted memory positions from stdin
-func parseCorruptedPositions(r io.Reader) ([]Position, error) {
-	var positions []Position
+func parseCorruptedPositions(r io.Reader) ([]grid.Point, error) {
+	var positions []grid.Point
 	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
-		coords := strings.Split(scanner.Text(), ",")
-		x, _ := strconv.Atoi(coords[0])
-		y, _ := strconv.Atoi(coords[1])
-		positions = append(positions, Position{x, y})
+		pos, err := ParsePoint(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, pos)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -121,37 +146,89 @@ func parseCorruptedPositions(r io.Reader) ([]Position, error) {
 	return positions, nil
 }
 
-func main() {
-	file, err := os.Open("input.txt")
+// ParsePoint reads a position written as "x,y"
+func ParsePoint(s string) (grid.Point, error) {
+	xs, ys, ok := strings.Cut(s, ",")
+	if !ok {
+		return grid.Point{}, fmt.Errorf("position %q is not x,y", s)
+	}
+	x, err := strconv.Atoi(strings.TrimSpace(xs))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		os.Exit(1)
+		return grid.Point{}, fmt.Errorf("position %q: %w", s, err)
 	}
-	defer file.Close()
-
-	corruptedPositions, err := parseCorruptedPositions(file)
+	y, err := strconv.Atoi(strings.TrimSpace(ys))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing corrupted positions: %v\n", err)
+		return grid.Point{}, fmt.Errorf("position %q: %w", s, err)
 	}
+	return grid.Point{X: x, Y: y}, nil
+}
+
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 18, func() aoc.Solver { return &Solver{options: DefaultOptions} })
+	aoc.Embed(2024, 18, input)
+}
 
-	grid := NewMemoryGrid()
+// Solver solves day 18 using the positions of the falling bytes
+type Solver struct {
+	options   Options
+	positions []grid.Point
+}
 
-	// Part 1: Find shortest path after first 1024 corrupted positions
-	for i := 0; i < 1024 && i < len(corruptedPositions); i++ {
-		grid.AddCorruption(corruptedPositions[i])
+// NewSolver returns a solver following options, whose layout must be valid
+func NewSolver(options Options) (*Solver, error) {
+	if err := options.Layout.Validate(); err != nil {
+		return nil, err
 	}
+	return &Solver{options: options}, nil
+}
 
-	if path := grid.FindShortestPath(); path != nil {
-		fmt.Println("Part one:", len(path))
+// Parse reads the falling byte positions in order
+func (s *Solver) Parse(r io.Reader) error {
+	positions, err := parseCorruptedPositions(r)
+	if err != nil {
+		return err
 	}
 
-	// Part 2: Find position that blocks path to exit
-	for _, pos := range corruptedPositions[1024:] {
-		grid.AddCorruption(pos)
-		if path := grid.FindShortestPath(); path == nil {
-			fmt.Printf("Part two:\n%d,%d\n", pos.x, pos.y)
-			return
+	s.positions = positions
+	return nil
+}
+
+// Part1 returns the minimum number of steps to the exit after the first
+// bytes have fallen, a kilobyte of them in the puzzle input. When the
+// Timed option is set it returns the earliest time the exit can be reached while the bytes
+// fall.
+func (s *Solver) Part1() (aoc.Answer, error) {
+	memory := NewMemoryGrid(s.options.Layout)
+	if s.options.Timed {
+		arrival, path := memory.EarliestArrival(s.positions)
+		if arrival < 0 {
+			return aoc.Answer{}, errors.New("no path to the exit")
+		}
+		if s.options.TimedLog != nil {
+			WriteTimedPath(s.options.TimedLog, path)
 		}
+		return aoc.Int(arrival), nil
 	}
+
+	for i := 0; i < s.options.Layout.Fallen && i < len(s.positions); i++ {
+		memory.AddCorruption(s.positions[i])
+	}
+
+	path := memory.FindShortestPath()
+	if path == nil {
+		return aoc.Answer{}, errors.New("no path to the exit")
+	}
+	return aoc.Int(len(path)), nil
 }
 
+// Part2 returns the coordinates of the first byte that cuts off the exit
+func (s *Solver) Part2() (aoc.Answer, error) {
+	pos, i := NewMemoryGrid(s.options.Layout).FirstBlockingByte(s.positions)
+	if i < 0 {
+		return aoc.Answer{}, errors.New("the exit is never cut off")
+	}
+	return aoc.Text(pos.String()), nil
+}
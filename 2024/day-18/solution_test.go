@@ -1,37 +1,39 @@
-package main
+package day18
 
 import (
+	"slices"
+	"strings"
 	"testing"
+
+	"aoc2024/aoc"
+	"aoc2024/grid"
 )
 
 func TestShortestPathWithCorruptedPositions(t *testing.T) {
-	GridSize = 6
-	exitPos = Position{GridSize, GridSize}
-
-	grid := NewMemoryGrid()
+	memory := NewMemoryGrid(ExampleLayout)
 
 	// Adding the first 12 corrupted positions from the example
-	corruptedPositions := []Position{
-		{5, 4},
-		{4, 2},
-		{4, 5},
-		{3, 0},
-		{2, 1},
-		{6, 3},
-		{2, 4},
-		{1, 5},
-		{0, 6},
-		{3, 3},
-		{2, 6},
-		{5, 1},
+	corruptedPositions := []grid.Point{
+		{X: 5, Y: 4},
+		{X: 4, Y: 2},
+		{X: 4, Y: 5},
+		{X: 3, Y: 0},
+		{X: 2, Y: 1},
+		{X: 6, Y: 3},
+		{X: 2, Y: 4},
+		{X: 1, Y: 5},
+		{X: 0, Y: 6},
+		{X: 3, Y: 3},
+		{X: 2, Y: 6},
+		{X: 5, Y: 1},
 	}
 
 	for _, pos := range corruptedPositions {
-		grid.AddCorruption(pos)
+		memory.AddCorruption(pos)
 	}
 
 	// Calculate shortest path
-	path := grid.FindShortestPath()
+	path := memory.FindShortestPath()
 
 	if path == nil {
 		t.Fatalf("Expected a path to the exit, but none was found")
@@ -44,48 +46,267 @@ func TestShortestPathWithCorruptedPositions(t *testing.T) {
 }
 
 func TestFirstByteBlockingExit(t *testing.T) {
-	GridSize = 6
-	exitPos = Position{GridSize, GridSize}
-
-	grid := NewMemoryGrid()
+	memory := NewMemoryGrid(ExampleLayout)
 
 	// Adding corrupted positions until the path is blocked
-	corruptedPositions := []Position{
-		{5, 4},
-		{4, 2},
-		{4, 5},
-		{3, 0},
-		{2, 1},
-		{6, 3},
-		{2, 4},
-		{1, 5},
-		{0, 6},
-		{3, 3},
-		{2, 6},
-		{5, 1},
-		{1, 2},
-		{5, 5},
-		{2, 5},
-		{6, 5},
-		{1, 4},
-		{0, 4},
-		{6, 4},
-		{1, 1}, // up to here path exists
-		{6, 1}, // this byte blocks the path
-	}
-
-	var blockingByte Position
+	corruptedPositions := []grid.Point{
+		{X: 5, Y: 4},
+		{X: 4, Y: 2},
+		{X: 4, Y: 5},
+		{X: 3, Y: 0},
+		{X: 2, Y: 1},
+		{X: 6, Y: 3},
+		{X: 2, Y: 4},
+		{X: 1, Y: 5},
+		{X: 0, Y: 6},
+		{X: 3, Y: 3},
+		{X: 2, Y: 6},
+		{X: 5, Y: 1},
+		{X: 1, Y: 2},
+		{X: 5, Y: 5},
+		{X: 2, Y: 5},
+		{X: 6, Y: 5},
+		{X: 1, Y: 4},
+		{X: 0, Y: 4},
+		{X: 6, Y: 4},
+		{X: 1, Y: 1}, // up to here path exists
+		{X: 6, Y: 1}, // this byte blocks the path
+	}
+
+	var blockingByte grid.Point
 	for _, pos := range corruptedPositions {
-		grid.AddCorruption(pos)
-		if path := grid.FindShortestPath(); path == nil {
+		memory.AddCorruption(pos)
+		if path := memory.FindShortestPath(); path == nil {
 			blockingByte = pos
 			break
 		}
 	}
 
-	expectedBlockingByte := Position{6, 1}
+	expectedBlockingByte := grid.Point{X: 6, Y: 1}
 	if blockingByte != expectedBlockingByte {
 		t.Errorf("Expected blocking byte at %v, but got %v", expectedBlockingByte, blockingByte)
 	}
 }
 
+func TestFirstBlockingByte(t *testing.T) {
+	example := []grid.Point{
+		{X: 5, Y: 4}, {X: 4, Y: 2}, {X: 4, Y: 5}, {X: 3, Y: 0}, {X: 2, Y: 1},
+		{X: 6, Y: 3}, {X: 2, Y: 4}, {X: 1, Y: 5}, {X: 0, Y: 6}, {X: 3, Y: 3},
+		{X: 2, Y: 6}, {X: 5, Y: 1}, {X: 1, Y: 2}, {X: 5, Y: 5}, {X: 2, Y: 5},
+		{X: 6, Y: 5}, {X: 1, Y: 4}, {X: 0, Y: 4}, {X: 6, Y: 4}, {X: 1, Y: 1},
+		{X: 6, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 5}, {X: 1, Y: 6}, {X: 2, Y: 0},
+	}
+
+	tests := []struct {
+		name      string
+		positions []grid.Point
+		wantPos   grid.Point
+		wantIndex int
+	}{
+		{"example", example, grid.Point{X: 6, Y: 1}, 20},
+		{"stops before the blocking byte", example[:20], grid.Point{}, -1},
+		{"repeated byte", append(slices.Clone(example[:20]), example[0]), grid.Point{}, -1},
+		{"byte on the exit", []grid.Point{{X: 1, Y: 1}, {X: 6, Y: 6}}, grid.Point{X: 6, Y: 6}, 1},
+		{"no bytes", nil, grid.Point{}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, i := NewMemoryGrid(ExampleLayout).FirstBlockingByte(tt.positions)
+			if pos != tt.wantPos || i != tt.wantIndex {
+				t.Errorf("FirstBlockingByte() = %v, %d, want %v, %d", pos, i, tt.wantPos, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestFirstBlockingByteKeepsCorruption(t *testing.T) {
+	memory := NewMemoryGrid(ExampleLayout)
+	memory.AddCorruption(grid.Point{X: 6, Y: 5})
+
+	pos, i := memory.FirstBlockingByte([]grid.Point{{X: 6, Y: 5}, {X: 5, Y: 6}})
+	if want := (grid.Point{X: 5, Y: 6}); pos != want || i != 1 {
+		t.Errorf("FirstBlockingByte() = %v, %d, want %v, 1", pos, i, want)
+	}
+	if memory.IsCorrupted(grid.Point{X: 5, Y: 6}) {
+		t.Error("FirstBlockingByte() corrupted the grid it was called on")
+	}
+}
+
+const exampleInput = `5,4
+4,2
+4,5
+3,0
+2,1
+6,3
+2,4
+1,5
+0,6
+3,3
+2,6
+5,1
+1,2
+5,5
+2,5
+6,5
+1,4
+0,4
+6,4
+1,1
+6,1
+1,0
+0,5
+1,6
+2,0
+`
+
+func TestSolverLayouts(t *testing.T) {
+	custom := Layout{Width: 7, Height: 7, Start: grid.Point{X: 6, Y: 6}, Exit: grid.Point{X: 0, Y: 0}, Fallen: 12}
+	offGrid := ExampleLayout
+	offGrid.Fallen = 14
+
+	tests := []struct {
+		name         string
+		layout       Layout
+		input        string
+		part1, part2 aoc.Answer
+	}{
+		{"example", ExampleLayout, exampleInput, aoc.Int(22), aoc.Text("6,1")},
+		{"puzzle input", DefaultLayout, input, aoc.Int(506), aoc.Text("62,6")},
+		{"reversed endpoints", custom, exampleInput, aoc.Int(22), aoc.Text("6,1")},
+		{"bytes outside the memory space", offGrid, "99,99\n-1,3\n" + exampleInput, aoc.Int(22), aoc.Text("6,1")},
+		{"puzzle input in the example space", ExampleLayout, input, aoc.Int(12), aoc.Text("1,6")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSolver(Options{Layout: tt.layout})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Parse(strings.NewReader(tt.input)); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := s.Part1(); err != nil || got != tt.part1 {
+				t.Errorf("Part1() = %v, %v, want %v", got, err, tt.part1)
+			}
+			if got, err := s.Part2(); err != nil || got != tt.part2 {
+				t.Errorf("Part2() = %v, %v, want %v", got, err, tt.part2)
+			}
+		})
+	}
+}
+
+func TestLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  Layout
+		wantErr bool
+	}{
+		{"default", DefaultLayout, false},
+		{"example", ExampleLayout, false},
+		{"empty", Layout{}, true},
+		{"exit outside", Layout{Width: 7, Height: 7, Exit: grid.Point{X: 7, Y: 6}}, true},
+		{"start outside", Layout{Width: 7, Height: 7, Start: grid.Point{X: -1, Y: 0}}, true},
+		{"negative fallen", Layout{Width: 7, Height: 7, Fallen: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEarliestArrival(t *testing.T) {
+	positions, err := parseCorruptedPositions(strings.NewReader(exampleInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arrival, path := NewMemoryGrid(ExampleLayout).EarliestArrival(positions)
+	if arrival != 12 || len(path) != arrival {
+		t.Fatalf("EarliestArrival() = %d with %d steps, want 12 with 12 steps", arrival, len(path))
+	}
+	pos := ExampleLayout.Start
+	for time, next := range path {
+		if pos.Manhattan(next) != 1 {
+			t.Errorf("step %d jumps from %v to %v", time+1, pos, next)
+		}
+		if i := slices.Index(positions, next); i >= 0 && i <= time+1 {
+			t.Errorf("step %d enters %v, which fell at time %d", time+1, next, i)
+		}
+		pos = next
+	}
+	if pos != ExampleLayout.Exit {
+		t.Errorf("path ends at %v, want the exit %v", pos, ExampleLayout.Exit)
+	}
+
+	// In a corridor of three cells, bytes outside it only pass the time
+	corridor := Layout{Width: 3, Height: 1, Exit: grid.Point{X: 2, Y: 0}}
+	away := grid.Point{X: 9, Y: 9}
+	tests := []struct {
+		name      string
+		positions []grid.Point
+		want      int
+	}{
+		{"no bytes", nil, 2},
+		{"byte on the start", []grid.Point{{X: 0, Y: 0}}, -1},
+		{"byte lands as the walker arrives", []grid.Point{away, {X: 1, Y: 0}}, -1},
+		{"byte lands after the walker leaves", []grid.Point{away, away, {X: 1, Y: 0}}, 2},
+		{"byte lands behind the walker", []grid.Point{away, {X: 0, Y: 0}}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := NewMemoryGrid(corridor).EarliestArrival(tt.positions); got != tt.want {
+				t.Errorf("EarliestArrival() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	memory := NewMemoryGrid(corridor)
+	memory.AddCorruption(grid.Point{X: 1, Y: 0})
+	if got, path := memory.EarliestArrival(nil); got != -1 || path != nil {
+		t.Errorf("EarliestArrival() past a corrupted cell = %d, %v, want -1, nil", got, path)
+	}
+}
+
+func TestNewSolver(t *testing.T) {
+	if _, err := NewSolver(Options{Layout: Layout{Width: 7, Height: 7, Exit: grid.Point{X: 9, Y: 9}}}); err == nil {
+		t.Error("NewSolver() accepted an exit outside the memory space")
+	}
+
+	// A timed solver and an untimed one side by side
+	var log strings.Builder
+	timed, err := NewSolver(Options{Layout: ExampleLayout, Timed: true, TimedLog: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+	untimed, err := NewSolver(Options{Layout: ExampleLayout})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		solver *Solver
+		want   aoc.Answer
+	}{
+		{"timed", timed, aoc.Int(12)},
+		{"untimed", untimed, aoc.Int(22)},
+	} {
+		if err := tt.solver.Parse(strings.NewReader(exampleInput)); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := tt.solver.Part1(); err != nil || got != tt.want {
+			t.Errorf("%s Part1() = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	if lines := strings.Count(log.String(), "\n"); lines != 12 {
+		t.Errorf("timed log has %d steps, want 12:\n%s", lines, log.String())
+	}
+}
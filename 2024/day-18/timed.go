@@ -0,0 +1,83 @@
+package day18
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"aoc2024/grid"
+)
+
+// EarliestArrival walks to the exit while the bytes fall: positions[i]
+// lands at time step i and blocks its cell from then on, and the walker
+// leaves the start at time 0 and moves one cell per step, never onto a cell
+// that has fallen by the time it gets there. Cells already corrupted on the
+// grid count as fallen from the start. It returns the time the exit is
+// first reached and the positions stepped onto, or -1 and nil if the bytes
+// always cut the walker off.
+//
+// The search is a BFS over (position, time) taken one time step at a time.
+// Bytes only ever block cells, so reaching a position later is never better
+// than reaching it earlier, and each position is kept at the first time
+// it is reached.
+func (g *MemoryGrid) EarliestArrival(positions []grid.Point) (int, []grid.Point) {
+	// The time each cell falls at, or -1 for cells that never fall
+	fallsAt := grid.New[int](g.corrupted.Width(), g.corrupted.Height())
+	for pos, corrupted := range g.corrupted.All() {
+		if !corrupted {
+			fallsAt.Set(pos, -1)
+		}
+	}
+	for i, pos := range positions {
+		if fallsAt.InBounds(pos) && fallsAt.At(pos) < 0 {
+			fallsAt.Set(pos, i)
+		}
+	}
+	open := func(pos grid.Point, time int) bool {
+		t := fallsAt.At(pos)
+		return t < 0 || t > time
+	}
+
+	if !open(g.start, 0) {
+		return -1, nil
+	}
+
+	parent := map[grid.Point]grid.Point{g.start: g.start}
+	frontier := []grid.Point{g.start}
+	for time := 0; len(frontier) > 0; time++ {
+		var next []grid.Point
+		for _, pos := range frontier {
+			if pos == g.exit {
+				return time, walkBack(parent, g.start, pos)
+			}
+			for n := range fallsAt.Neighbours4(pos) {
+				if _, seen := parent[n]; seen || !open(n, time+1) {
+					continue
+				}
+				parent[n] = pos
+				next = append(next, n)
+			}
+		}
+		frontier = next
+	}
+	return -1, nil
+}
+
+// walkBack follows the parent links from the end back to the start and
+// returns the positions stepped onto in walking order
+func walkBack(parent map[grid.Point]grid.Point, start, end grid.Point) []grid.Point {
+	var path []grid.Point
+	for pos := end; pos != start; pos = parent[pos] {
+		path = append(path, pos)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// WriteTimedPath lists a walk one step per line with the time each
+// position is reached
+func WriteTimedPath(w io.Writer, path []grid.Point) {
+	for i, pos := range path {
+		fmt.Fprintf(w, "step %d: %v\n", i+1, pos)
+	}
+}
@@ -0,0 +1,11 @@
+package day19
+
+import (
+	"testing"
+
+	"aoc2024/aoc/aoctest"
+)
+
+func BenchmarkParse(b *testing.B) { aoctest.BenchmarkParse(b, 2024, 19) }
+func BenchmarkPart1(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 19, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.BenchmarkPart(b, 2024, 19, 2) }
@@ -1,11 +1,13 @@
-package main
+package day19
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
-	"os"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 // isDesignPossible checks if a given design can be formed using the towel patterns.
@@ -101,32 +103,43 @@ func parseInput(r io.Reader) ([]string, []string, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		fmt.Printf("Error reading file: %v\n", err)
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("reading input: %w", err)
 	}
 
 	return patterns, designs, nil
 }
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Printf("Error opening file: %v\n", err)
-		return
-	}
-	defer file.Close()
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 19, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 19, input)
+}
 
-	patterns, designs, err := parseInput(file)
+// Solver solves day 19 using the towel patterns and desired designs
+type Solver struct {
+	patterns, designs []string
+}
+
+// Parse reads the available patterns followed by the designs
+func (s *Solver) Parse(r io.Reader) error {
+	patterns, designs, err := parseInput(r)
 	if err != nil {
-		fmt.Println("Error parsing input")
-		return
+		return err
 	}
 
-	// Count possible designs
-	possibleCount := countPossibleDesigns(patterns, designs)
-	fmt.Printf("Number of possible designs: %d\n", possibleCount)
+	s.patterns = patterns
+	s.designs = designs
+	return nil
+}
+
+// Part1 returns the number of designs that can be made
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(countPossibleDesigns(s.patterns, s.designs)), nil
+}
 
-	// Count total ways to form designs
-	totalWays := countTotalWays(patterns, designs)
-	fmt.Printf("Total ways to form designs: %d\n", totalWays)
+// Part2 returns the total number of ways the designs can be made
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return aoc.Int(countTotalWays(s.patterns, s.designs)), nil
 }
@@ -1,35 +1,21 @@
-package main
+package day20
 
 import (
-	"bufio"
+	_ "embed"
+	"errors"
 	"fmt"
 	"io"
-	"os"
-	"slices"
-)
-
-// Position represents a coordinate in the maze
-type Position struct {
-	row, col int
-}
-
-// Direction represents possible movement vectors
-type Direction struct {
-	deltaRow, deltaCol int
-}
+	"iter"
 
-// MovementDirections defines all possible single-step movements
-var MovementDirections = []Direction{
-	{0, 1},  // right
-	{1, 0},  // down
-	{-1, 0}, // up
-	{0, -1}, // left
-}
+	"aoc2024/aoc"
+	"aoc2024/grid"
+	"aoc2024/search"
+)
 
 // MazeConfig holds the maze configuration and parameters
 type MazeConfig struct {
-	walls         map[Position]struct{}
-	start, finish Position
+	track         *grid.Grid[byte]
+	start, finish grid.Point
 }
 
 // CheatParams holds the parameters for calculating valid cheats
@@ -40,82 +26,58 @@ type CheatParams struct {
 
 // ParseMaze reads the maze layout from input and returns the configuration
 func ParseMaze(r io.Reader) (MazeConfig, error) {
-	walls := make(map[Position]struct{})
-	var start, finish Position
-
-	scanner := bufio.NewScanner(r)
-	for row := 0; scanner.Scan(); row++ {
-		for col, ch := range scanner.Text() {
-			pos := Position{row, col}
-			switch ch {
-			case '#':
-				walls[pos] = struct{}{}
-			case 'S':
-				start = pos
-			case 'E':
-				finish = pos
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
+	track, err := grid.Parse(r, grid.AsByte)
+	if err != nil {
 		return MazeConfig{}, fmt.Errorf("reading maze: %w", err)
 	}
 
-	return MazeConfig{walls, start, finish}, nil
-}
-
-// FindShortestPath uses BFS to find the shortest valid path through the maze
-func (mc *MazeConfig) FindShortestPath() []Position {
-	queue := []Position{mc.start}
-	visited := map[Position]struct{}{mc.start: {}}
-	parent := make(map[Position]Position)
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		if current == mc.finish {
-			return reconstructPath(parent, mc.start, mc.finish)
+	var start, finish grid.Point
+	for pos, ch := range track.All() {
+		switch ch {
+		case 'S':
+			start = pos
+		case 'E':
+			finish = pos
 		}
+	}
 
-		for _, dir := range MovementDirections {
-			next := Position{
-				row: current.row + dir.deltaRow,
-				col: current.col + dir.deltaCol,
-			}
+	return MazeConfig{track, start, finish}, nil
+}
 
-			if _, isWall := mc.walls[next]; isWall {
-				continue
-			}
+// isWall reports whether a position is blocked, treating the area outside
+// the maze as solid wall
+func (mc *MazeConfig) isWall(pos grid.Point) bool {
+	ch, ok := mc.track.Get(pos)
+	return !ok || ch == '#'
+}
 
-			if _, seen := visited[next]; !seen {
-				visited[next] = struct{}{}
-				parent[next] = current
-				queue = append(queue, next)
-			}
-		}
+// FindShortestPath uses BFS to find the shortest valid path through the maze
+func (mc *MazeConfig) FindShortestPath() []grid.Point {
+	path, found := search.BFS(mc.start, mc.trackNeighbours, func(p grid.Point) bool { return p == mc.finish })
+	if !found {
+		return nil
 	}
-	return nil
+	return path.States
 }
 
-// reconstructPath builds the path from start to finish using the parent map
-func reconstructPath(parent map[Position]Position, start, finish Position) []Position {
-	path := []Position{finish}
-	for curr := finish; curr != start; curr = parent[curr] {
-		path = append(path, parent[curr])
+// trackNeighbours yields the neighbouring positions that are not walls
+func (mc *MazeConfig) trackNeighbours(pos grid.Point) iter.Seq[grid.Point] {
+	return func(yield func(grid.Point) bool) {
+		for _, next := range pos.Neighbours4() {
+			if !mc.isWall(next) && !yield(next) {
+				return
+			}
+		}
 	}
-	slices.Reverse(path)
-	return path
 }
 
 // CountValidCheats calculates the number of valid cheating opportunities
-func CountValidCheats(path []Position, params CheatParams) int {
+func CountValidCheats(path []grid.Point, params CheatParams) int {
 	validCheats := 0
 
 	for i := 0; i < len(path)-1; i++ {
 		for j := i + 1; j < len(path); j++ {
-			cheatDistance := ManhattanDistance(path[i], path[j])
+			cheatDistance := path[i].Manhattan(path[j])
 			if cheatDistance > 0 && cheatDistance <= params.maxDistance {
 				timeSaved := j - i - cheatDistance
 				if timeSaved >= params.minTimeSaved {
@@ -127,49 +89,53 @@ func CountValidCheats(path []Position, params CheatParams) int {
 	return validCheats
 }
 
-// ManhattanDistance calculates the Manhattan distance between two positions
-func ManhattanDistance(p1, p2 Position) int {
-	return abs(p2.row-p1.row) + abs(p2.col-p1.col)
+// Minimum number of picoseconds a cheat has to save to be counted
+const minTimeSaved = 100
+
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 20, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 20, input)
 }
 
-func abs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
+// Solver solves day 20 using the racetrack
+type Solver struct {
+	maze MazeConfig
 }
 
-func main() {
-	file, err := os.Open("input.txt")
+// Parse reads the racetrack
+func (s *Solver) Parse(r io.Reader) error {
+	maze, err := ParseMaze(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
-		os.Exit(1)
+		return err
 	}
-	defer file.Close()
 
-	maze, err := ParseMaze(file)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing maze: %v\n", err)
-		os.Exit(1)
-	}
+	s.maze = maze
+	return nil
+}
+
+// Part1 returns the number of 2 picosecond cheats that save enough time
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return s.countCheats(2)
+}
+
+// Part2 returns the number of 20 picosecond cheats that save enough time
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return s.countCheats(20)
+}
 
-	path := maze.FindShortestPath()
+// countCheats counts the cheats of up to maxDistance picoseconds that save
+// at least minTimeSaved picoseconds along the racetrack
+func (s *Solver) countCheats(maxDistance int) (aoc.Answer, error) {
+	path := s.maze.FindShortestPath()
 	if path == nil {
-		fmt.Fprintf(os.Stderr, "No valid path found through maze\n")
-		os.Exit(1)
+		return aoc.Answer{}, errors.New("no valid path found through maze")
 	}
 
-	// Part One: 2-step cheats, need to save at least 100 steps
-	partOne := CountValidCheats(path, CheatParams{
-		maxDistance:  2,
-		minTimeSaved: 100,
-	})
-	fmt.Printf("Part One: %d\n", partOne)
-
-	// Part Two: 20-step cheats, need to save at least 100 steps
-	partTwo := CountValidCheats(path, CheatParams{
-		maxDistance:  20,
-		minTimeSaved: 100,
-	})
-	fmt.Printf("Part Two: %d\n", partTwo)
+	return aoc.Int(CountValidCheats(path, CheatParams{
+		maxDistance:  maxDistance,
+		minTimeSaved: minTimeSaved,
+	})), nil
 }
@@ -1,8 +1,10 @@
-package main
+package day20
 
 import (
 	"strings"
 	"testing"
+
+	"aoc2024/grid"
 )
 
 const exampleMaze = `###############
@@ -28,9 +30,9 @@ func TestParseMaze(t *testing.T) {
 		t.Fatalf("ParseMaze returned unexpected error: %v", err)
 	}
 
-	// Check start position (3,1) and finish position (7,5)
-	expectedStart := Position{3, 1}
-	expectedFinish := Position{7, 5}
+	// Check start position (1,3) and finish position (5,7)
+	expectedStart := grid.Point{X: 1, Y: 3}
+	expectedFinish := grid.Point{X: 5, Y: 7}
 
 	if maze.start != expectedStart {
 		t.Errorf("wrong start position: got %v, want %v", maze.start, expectedStart)
@@ -41,18 +43,18 @@ func TestParseMaze(t *testing.T) {
 
 	// Verify some wall positions
 	wallTests := []struct {
-		pos      Position
+		pos      grid.Point
 		expected bool
 	}{
-		{Position{0, 0}, true},   // corner wall
-		{Position{1, 1}, false},  // path
-		{Position{3, 1}, false},  // start position
-		{Position{7, 5}, false},  // end position
-		{Position{14, 14}, true}, // bottom-right corner
+		{grid.Point{X: 0, Y: 0}, true},   // corner wall
+		{grid.Point{X: 1, Y: 1}, false},  // path
+		{grid.Point{X: 1, Y: 3}, false},  // start position
+		{grid.Point{X: 5, Y: 7}, false},  // end position
+		{grid.Point{X: 14, Y: 14}, true}, // bottom-right corner
 	}
 
 	for _, tt := range wallTests {
-		_, isWall := maze.walls[tt.pos]
+		isWall := maze.isWall(tt.pos)
 		if isWall != tt.expected {
 			t.Errorf("position %v: got wall=%v, want wall=%v", tt.pos, isWall, tt.expected)
 		}
@@ -150,7 +152,7 @@ func TestCountValidCheats(t *testing.T) {
 			// Count cheats for each amount of time saved
 			for i := 0; i < len(path)-1; i++ {
 				for j := i + 1; j < len(path); j++ {
-					dist := ManhattanDistance(path[i], path[j])
+					dist := path[i].Manhattan(path[j])
 					if dist > 0 && dist <= tt.params.maxDistance {
 						timeSaved := j - i - dist
 						if timeSaved >= tt.params.minTimeSaved {
@@ -174,20 +176,20 @@ func TestCountValidCheats(t *testing.T) {
 
 func TestManhattanDistance(t *testing.T) {
 	tests := []struct {
-		p1, p2 Position
+		p1, p2 grid.Point
 		want   int
 	}{
-		{Position{0, 0}, Position{3, 0}, 3},
-		{Position{0, 0}, Position{0, 5}, 5},
-		{Position{1, 1}, Position{4, 5}, 7},
-		{Position{3, 2}, Position{0, 0}, 5},
-		{Position{2, 2}, Position{2, 2}, 0},
+		{grid.Point{X: 0, Y: 0}, grid.Point{X: 0, Y: 3}, 3},
+		{grid.Point{X: 0, Y: 0}, grid.Point{X: 5, Y: 0}, 5},
+		{grid.Point{X: 1, Y: 1}, grid.Point{X: 5, Y: 4}, 7},
+		{grid.Point{X: 2, Y: 3}, grid.Point{X: 0, Y: 0}, 5},
+		{grid.Point{X: 2, Y: 2}, grid.Point{X: 2, Y: 2}, 0},
 	}
 
 	for _, tt := range tests {
-		got := ManhattanDistance(tt.p1, tt.p2)
+		got := tt.p1.Manhattan(tt.p2)
 		if got != tt.want {
-			t.Errorf("ManhattanDistance(%v, %v) = %d, want %d",
+			t.Errorf("Manhattan(%v, %v) = %d, want %d",
 				tt.p1, tt.p2, got, tt.want)
 		}
 	}
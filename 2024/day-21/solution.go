@@ -1,12 +1,14 @@
-package main
+package day21
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
 	"io"
-	"os"
 	"strconv"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 // Represents a coordinate on a keypad
@@ -189,24 +191,22 @@ func ParseNumericPart(code string) (int, error) {
 	return strconv.Atoi(numericPart)
 }
 
-func calculateComplexitySum(codes []string, robotChain *RobotChain, depth int) int {
+func calculateComplexitySum(codes []string, robotChain *RobotChain, depth int) (int, error) {
 	sum := 0
 	for _, code := range codes {
 		seqLength, err := robotChain.GenerateButtonSequence(code, depth)
 		if err != nil {
-			fmt.Printf("Error processing code %s: %v\n", code, err)
-			continue
+			return 0, fmt.Errorf("processing code %s: %w", code, err)
 		}
 
 		numericPart, err := ParseNumericPart(code)
 		if err != nil {
-			fmt.Printf("Error parsing numeric part of code %s: %v\n", code, err)
-			continue
+			return 0, fmt.Errorf("parsing numeric part of code %s: %w", code, err)
 		}
 
 		sum += seqLength * numericPart
 	}
-	return sum
+	return sum, nil
 }
 
 // parseFile reads the contents of a file and returns two slices of integers
@@ -218,29 +218,49 @@ func parseFile(r io.Reader) ([]string, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-		return nil, err
+		return nil, fmt.Errorf("reading input: %w", err)
 	}
 	return codes, nil
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 21, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 21, input)
+}
+
+// Solver solves day 21 using the door codes
+type Solver struct {
+	codes []string
+}
+
+// Parse reads one door code per line
+func (s *Solver) Parse(r io.Reader) error {
+	codes, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return err
 	}
-	defer file.Close()
 
-	codes, err := parseFile(file)
+	s.codes = codes
+	return nil
+}
+
+// Part1 returns the sum of the complexities with two directional robots
+func (s *Solver) Part1() (aoc.Answer, error) {
+	sum, err := calculateComplexitySum(s.codes, NewRobotChain(), 2)
 	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
+		return aoc.Answer{}, err
 	}
+	return aoc.Int(sum), nil
+}
 
-	robotChain := NewRobotChain()
-
-	fmt.Println("Part One:", calculateComplexitySum(codes, robotChain, 2))
-	fmt.Println("Part Two:", calculateComplexitySum(codes, robotChain, 25))
+// Part2 returns the sum of the complexities with 25 directional robots
+func (s *Solver) Part2() (aoc.Answer, error) {
+	sum, err := calculateComplexitySum(s.codes, NewRobotChain(), 25)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Int(sum), nil
 }
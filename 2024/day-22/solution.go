@@ -1,11 +1,12 @@
-package main
+package day22
 
 import (
 	"bufio"
-	"fmt"
+	_ "embed"
 	"io"
-	"os"
 	"strconv"
+
+	"aoc2024/aoc"
 )
 
 const (
@@ -142,31 +143,53 @@ func (ms *MarketSimulator) calculateMaxBananas(
 	return maxBananas
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+// copyBuyers returns independent copies of the buyers so that simulating
+// them leaves the originals untouched
+func copyBuyers(buyers []*Buyer) []*Buyer {
+	copies := make([]*Buyer, len(buyers))
+	for i, buyer := range buyers {
+		buyerCopy := *buyer
+		copies[i] = &buyerCopy
 	}
-	defer file.Close()
+	return copies
+}
+
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 22, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 22, input)
+}
 
-	buyers, err := parseFile(file)
+// Solver solves day 22 using the buyers' initial secret numbers
+type Solver struct {
+	buyers []*Buyer
+}
+
+// Parse reads one initial secret number per line
+func (s *Solver) Parse(r io.Reader) error {
+	buyers, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
+		return err
 	}
 
-	// Part 1: Sum of 2000th secret for each buyer
+	s.buyers = buyers
+	return nil
+}
+
+// Part1 returns the sum of every buyer's 2000th secret number
+func (s *Solver) Part1() (aoc.Answer, error) {
 	totalSecret := 0
-	for _, buyer := range buyers {
-		buyerCopy := *buyer // Create copy to not affect Part 2
-		totalSecret += buyerCopy.simulateBuyerSequence()
+	for _, buyer := range copyBuyers(s.buyers) {
+		totalSecret += buyer.simulateBuyerSequence()
 	}
-	fmt.Println("Part 1:", totalSecret)
+	return aoc.Int(totalSecret), nil
+}
 
-	// Part 2: Find optimal trading sequence
-	simulator := &MarketSimulator{buyers: buyers}
-	maxBananas := simulator.findOptimalTradeSequence()
-	fmt.Println("Part 2:", maxBananas)
+// Part2 returns the most bananas that a single sequence of price changes
+// can buy
+func (s *Solver) Part2() (aoc.Answer, error) {
+	simulator := &MarketSimulator{buyers: copyBuyers(s.buyers)}
+	return aoc.Int(simulator.findOptimalTradeSequence()), nil
 }
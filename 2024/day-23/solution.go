@@ -1,12 +1,13 @@
-package main
+package day23
 
 import (
 	"bufio"
-	"fmt"
+	_ "embed"
 	"io"
-	"os"
 	"sort"
 	"strings"
+
+	"aoc2024/aoc"
 )
 
 // ComputerSet represents a set of connected computers
@@ -121,22 +122,9 @@ func parseFile(r io.Reader) (NetworkGraph, error) {
 	return network, nil
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer file.Close()
-
-	network, err := parseFile(file)
-	if err != nil {
-		fmt.Println("Error parsing file:", err)
-		return
-	}
-
-	// Part 1: Find triplets with at least one 't' computer
+// countTripletsWithT counts the groups of three interconnected computers
+// where at least one computer's name starts with 't'
+func countTripletsWithT(network NetworkGraph) int {
 	groups := network.AllComputers()            // Start with single computers
 	pairs := network.FindLargerGroups(groups)   // Find pairs
 	triplets := network.FindLargerGroups(pairs) // Find triplets
@@ -152,9 +140,12 @@ func main() {
 			}
 		}
 	}
-	fmt.Println("Triplets with 't' computers:", tComputerCount)
+	return tComputerCount
+}
 
-	// Part 2: Find the largest fully connected group (maximum clique)
+// findLANPartyPassword returns the sorted names of the largest fully
+// connected group of computers (the maximum clique)
+func findLANPartyPassword(network NetworkGraph) string {
 	currentGroups := network.AllComputers()
 	var lastValidGroups ComputerSet
 
@@ -163,9 +154,43 @@ func main() {
 		currentGroups = network.FindLargerGroups(currentGroups)
 	}
 
-	// Print the first (and only) maximum clique found
+	// Return the first (and only) maximum clique found
 	for password := range lastValidGroups {
-		fmt.Println("LAN Party password:", password)
-		return
+		return password
+	}
+	return ""
+}
+
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 23, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 23, input)
+}
+
+// Solver solves day 23 using the network map
+type Solver struct {
+	network NetworkGraph
+}
+
+// Parse reads one connection between two computers per line
+func (s *Solver) Parse(r io.Reader) error {
+	network, err := parseFile(r)
+	if err != nil {
+		return err
 	}
+
+	s.network = network
+	return nil
+}
+
+// Part1 returns the number of triplets containing a 't' computer
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(countTripletsWithT(s.network)), nil
+}
+
+// Part2 returns the password to the LAN party
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return aoc.Text(findLANPartyPassword(s.network)), nil
 }
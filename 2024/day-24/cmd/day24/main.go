@@ -0,0 +1,23 @@
+// Command day24 solves Advent of Code 2024 day 24. It reads the file given
+// with -input, or standard input for "-input -", and otherwise uses the
+// puzzle input embedded in the binary. With -graph it also writes the
+// circuit as a Graphviz (.dot) or Mermaid (.mmd) graph, and with -repair
+// part 2 searches for the swaps that make the circuit add.
+package main
+
+import (
+	"flag"
+
+	"aoc2024/aoc"
+	day24 "aoc2024/day-24"
+)
+
+func main() {
+	graph := flag.String("graph", "", "write the circuit to this .dot or .mmd file, highlighting faulty gates")
+	repair := flag.Bool("repair", false, "find the swapped wires by searching for swaps that repair the adder")
+
+	aoc.Main(2024, 24, func() {
+		day24.GraphPath = *graph
+		day24.SearchSwaps = *repair
+	})
+}
@@ -0,0 +1,148 @@
+package day24
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// gateColors gives each operation its own fill colour in exported graphs
+var gateColors = map[string]string{
+	"AND":  "#9ecae1",
+	"OR":   "#a1d99b",
+	"XOR":  "#fdd0a2",
+	"NAND": "#6baed6",
+	"NOR":  "#74c476",
+	"XNOR": "#fdae6b",
+	"NOT":  "#bcbddc",
+}
+
+// faultyColor outlines the gates that break the ripple-carry adder rules
+const faultyColor = "#e31a1c"
+
+// gateColor returns the fill colour of an operation
+func gateColor(operation string) string {
+	if color, ok := gateColors[operation]; ok {
+		return color
+	}
+	return "#d9d9d9"
+}
+
+// wires returns the names of every wire in the circuit in sorted order
+func (c *Circuit) wires() []string {
+	seen := make(map[string]bool)
+	for wire := range c.wireValues {
+		seen[wire] = true
+	}
+	for _, gate := range c.gates {
+		for _, input := range gate.inputs() {
+			seen[input] = true
+		}
+		seen[gate.output] = true
+	}
+
+	wires := make([]string, 0, len(seen))
+	for wire := range seen {
+		wires = append(wires, wire)
+	}
+	sort.Strings(wires)
+	return wires
+}
+
+// ExportDOT writes the circuit as a Graphviz graph. Wires are ellipses and
+// gates are boxes coloured by operation, with the gates that break the
+// adder rules outlined in red.
+func (c *Circuit) ExportDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph circuit {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [shape=ellipse, fontname=\"monospace\"];")
+
+	for _, wire := range c.wires() {
+		fmt.Fprintf(bw, "  %q;\n", wire)
+	}
+	for i, gate := range c.gates {
+		id := fmt.Sprintf("g%d", i)
+		attrs := fmt.Sprintf("shape=box, style=filled, fillcolor=%q, label=%q", gateColor(gate.operation), gate.operation)
+		if c.isGateFaulty(gate) {
+			attrs += fmt.Sprintf(", color=%q, penwidth=3", faultyColor)
+		}
+		fmt.Fprintf(bw, "  %s [%s];\n", id, attrs)
+		for _, input := range gate.inputs() {
+			fmt.Fprintf(bw, "  %q -> %s;\n", input, id)
+		}
+		fmt.Fprintf(bw, "  %s -> %q;\n", id, gate.output)
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// ExportMermaid writes the circuit as a Mermaid flowchart styled in the same
+// way as ExportDOT.
+func (c *Circuit) ExportMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "flowchart LR")
+
+	// Wire names are prefixed so that names such as "end" are not taken
+	// for Mermaid keywords
+	for _, wire := range c.wires() {
+		fmt.Fprintf(bw, "  w_%s([%s])\n", wire, wire)
+	}
+
+	classes := make(map[string]bool)
+	for i, gate := range c.gates {
+		id := fmt.Sprintf("g%d", i)
+		class := strings.ToLower(gate.operation)
+		classes[gate.operation] = true
+
+		fmt.Fprintf(bw, "  %s[%s]:::%s\n", id, gate.operation, class)
+		for _, input := range gate.inputs() {
+			fmt.Fprintf(bw, "  w_%s --> %s\n", input, id)
+		}
+		fmt.Fprintf(bw, "  %s --> w_%s\n", id, gate.output)
+		if c.isGateFaulty(gate) {
+			fmt.Fprintf(bw, "  class %s faulty\n", id)
+		}
+	}
+
+	operations := make([]string, 0, len(classes))
+	for operation := range classes {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	for _, operation := range operations {
+		fmt.Fprintf(bw, "  classDef %s fill:%s\n", strings.ToLower(operation), gateColor(operation))
+	}
+	fmt.Fprintf(bw, "  classDef faulty stroke:%s,stroke-width:3px\n", faultyColor)
+	return bw.Flush()
+}
+
+// ExportGraph writes the circuit in the format implied by the extension of
+// the file it is destined for: Mermaid for .mmd or .mermaid and Graphviz DOT
+// otherwise.
+func (c *Circuit) ExportGraph(w io.Writer, path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mmd", ".mermaid":
+		return c.ExportMermaid(w)
+	default:
+		return c.ExportDOT(w)
+	}
+}
+
+// writeGraphFile exports the circuit to the named file
+func (c *Circuit) writeGraphFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating graph: %w", err)
+	}
+	if err := c.ExportGraph(file, path); err != nil {
+		file.Close()
+		return fmt.Errorf("writing graph: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,271 @@
+package day24
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Swap is a pair of gates whose output wires are exchanged
+type Swap [2]string
+
+// ErrNoRepair is returned when no combination of swaps makes the circuit add
+var ErrNoRepair = errors.New("no set of output swaps makes the circuit an adder")
+
+const (
+	// neighbourhoodDepth bounds how far from a faulty bit the repair looks
+	// for gates to swap
+	neighbourhoodDepth = 3
+
+	// verifyTrials is the number of random additions a repaired circuit must
+	// get right
+	verifyTrials = 64
+)
+
+// Clone returns a copy of the circuit that can be changed independently
+func (c *Circuit) Clone() *Circuit {
+	wireValues := make(map[string]int, len(c.wireValues))
+	for wire, value := range c.wireValues {
+		wireValues[wire] = value
+	}
+	return &Circuit{
+		wireValues: wireValues,
+		gates:      append([]Gate(nil), c.gates...),
+		lastOutput: c.lastOutput,
+	}
+}
+
+// SwapOutputs exchanges the output wires of the gates driving a and b
+func (c *Circuit) SwapOutputs(a, b string) error {
+	ia, ib := -1, -1
+	for i, gate := range c.gates {
+		switch gate.output {
+		case a:
+			ia = i
+		case b:
+			ib = i
+		}
+	}
+	if ia < 0 || ib < 0 {
+		return fmt.Errorf("cannot swap %s and %s: both must be gate outputs", a, b)
+	}
+
+	c.gates[ia].output, c.gates[ib].output = b, a
+	return nil
+}
+
+// inputWidth returns the number of bits of each adder operand
+func (c *Circuit) inputWidth() int {
+	inputs := make(map[string]bool)
+	for _, gate := range c.gates {
+		for _, wire := range gate.inputs() {
+			if strings.HasPrefix(wire, "x") {
+				inputs[wire] = true
+			}
+		}
+	}
+	return len(inputs)
+}
+
+// wireName returns the name of bit i of an operand or the result
+func wireName(prefix string, i int) string {
+	return fmt.Sprintf("%s%02d", prefix, i)
+}
+
+// adder runs a circuit as the sum of its x and y operands
+type adder struct {
+	sim   *Simulator
+	width int
+}
+
+// adder prepares the circuit for adding, or returns false if its gates
+// form a loop
+func (c *Circuit) adder() (*adder, bool) {
+	sim, err := NewSimulator(c)
+	if err != nil {
+		return nil, false
+	}
+	return &adder{sim: sim, width: c.inputWidth()}, true
+}
+
+// add runs the circuit on two operands and returns the number it outputs,
+// or false if an output wire was never driven
+func (a *adder) add(x, y *big.Int) (*big.Int, bool) {
+	if a.sim.SetNumber("x", x) != nil || a.sim.SetNumber("y", y) != nil || a.sim.Run() != nil {
+		return nil, false
+	}
+	sum, err := a.sim.Output("z")
+	return sum, err == nil
+}
+
+// bitWorks checks that bit k adds correctly, with and without a carry into
+// it, by trying every combination of the operand bits involved
+func (a *adder) bitWorks(k int) bool {
+	for pattern := 0; pattern < 8; pattern++ {
+		carry := pattern >> 2
+		if carry == 1 && k == 0 {
+			continue
+		}
+
+		x, y := new(big.Int), new(big.Int)
+		x.SetBit(x, k, uint(pattern&1))
+		y.SetBit(y, k, uint(pattern>>1&1))
+		if carry == 1 {
+			x.SetBit(x, k-1, 1)
+			y.SetBit(y, k-1, 1)
+		}
+
+		got, ok := a.add(x, y)
+		if !ok || got.Cmp(new(big.Int).Add(x, y)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// firstFaultyBit returns the lowest bit from start up that does not add
+// correctly, or -1 if every one does
+func (a *adder) firstFaultyBit(start int) int {
+	for k := start; k < a.width; k++ {
+		if !a.bitWorks(k) {
+			return k
+		}
+	}
+	return -1
+}
+
+// worksBelow reports whether every bit below k adds correctly
+func (a *adder) worksBelow(k int) bool {
+	for i := 0; i < k; i++ {
+		if !a.bitWorks(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyAdder checks that the circuit adds random operands correctly
+func (c *Circuit) VerifyAdder(rng *rand.Rand) bool {
+	a, ok := c.adder()
+	if !ok {
+		return false
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(a.width))
+	for trial := 0; trial < verifyTrials; trial++ {
+		x := new(big.Int).Rand(rng, limit)
+		y := new(big.Int).Rand(rng, limit)
+
+		got, ok := a.add(x, y)
+		if !ok || got.Cmp(new(big.Int).Add(x, y)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// neighbourhood returns the outputs of the gates close to bit k: those fed
+// by its operand bits and their consumers, and those feeding its output
+// wires, up to neighbourhoodDepth gates away.
+func (c *Circuit) neighbourhood(k int) []string {
+	drivers := make(map[string]Gate, len(c.gates))
+	consumers := make(map[string][]Gate)
+	for _, gate := range c.gates {
+		drivers[gate.output] = gate
+		for _, input := range gate.inputs() {
+			consumers[input] = append(consumers[input], gate)
+		}
+	}
+
+	found := make(map[string]bool)
+	forward := []string{wireName("x", k), wireName("y", k)}
+	backward := []string{wireName("z", k), wireName("z", k+1)}
+	for depth := 0; depth < neighbourhoodDepth; depth++ {
+		var nextForward, nextBackward []string
+		for _, wire := range forward {
+			for _, gate := range consumers[wire] {
+				found[gate.output] = true
+				nextForward = append(nextForward, gate.output)
+			}
+		}
+		for _, wire := range backward {
+			if gate, ok := drivers[wire]; ok {
+				found[gate.output] = true
+				nextBackward = append(nextBackward, gate.inputs()...)
+			}
+		}
+		forward, backward = nextForward, nextBackward
+	}
+
+	wires := make([]string, 0, len(found))
+	for wire := range found {
+		wires = append(wires, wire)
+	}
+	sort.Strings(wires)
+	return wires
+}
+
+// Repair searches for at most maxSwaps output swaps that turn the circuit
+// into a working ripple-carry adder of whatever width its inputs have. It
+// fixes the lowest faulty bit first, trying every swap among the gates near
+// that bit and keeping one that makes the bit work, and backtracks when a
+// choice leads nowhere. The repaired copy must then add random operands
+// correctly. The circuit itself is left unchanged.
+func (c *Circuit) Repair(maxSwaps int, rng *rand.Rand) ([]Swap, error) {
+	// Every bit below start is known to add correctly
+	var search func(circuit *Circuit, start int, swaps []Swap) ([]Swap, bool)
+	search = func(circuit *Circuit, start int, swaps []Swap) ([]Swap, bool) {
+		a, ok := circuit.adder()
+		if !ok {
+			return nil, false
+		}
+		faulty := a.firstFaultyBit(start)
+		if faulty < 0 {
+			return swaps, circuit.VerifyAdder(rng)
+		}
+		if len(swaps) == maxSwaps {
+			return nil, false
+		}
+
+		candidates := circuit.neighbourhood(faulty)
+		for i, a := range candidates {
+			for _, b := range candidates[i+1:] {
+				repaired := circuit.Clone()
+				if err := repaired.SwapOutputs(a, b); err != nil {
+					continue
+				}
+
+				// The swap must fix the faulty bit without breaking a lower
+				// one. Checking the faulty bit first rejects most swaps cheaply.
+				fixed, ok := repaired.adder()
+				if !ok || !fixed.bitWorks(faulty) || !fixed.worksBelow(faulty) {
+					continue
+				}
+				if found, ok := search(repaired, faulty+1, append(swaps, Swap{a, b})); ok {
+					return found, true
+				}
+			}
+		}
+		return nil, false
+	}
+
+	swaps, ok := search(c.Clone(), 0, nil)
+	if !ok {
+		return nil, ErrNoRepair
+	}
+	return swaps, nil
+}
+
+// SwappedWires lists every wire involved in the swaps in sorted order,
+// joined by commas
+func SwappedWires(swaps []Swap) string {
+	var wires []string
+	for _, swap := range swaps {
+		wires = append(wires, swap[0], swap[1])
+	}
+	sort.Strings(wires)
+	return strings.Join(wires, ",")
+}
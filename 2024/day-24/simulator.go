@@ -0,0 +1,238 @@
+package day24
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrCycle is returned for circuits in which a gate depends on its own
+	// output
+	ErrCycle = errors.New("circuit has a cycle")
+
+	// ErrUndriven is returned when a gate reads a wire that no gate drives
+	// and that has not been given a value
+	ErrUndriven = errors.New("wire has no value")
+
+	// ErrMultipleDrivers is returned for circuits in which two gates drive
+	// the same wire
+	ErrMultipleDrivers = errors.New("wire is driven by more than one gate")
+)
+
+// simGate is a gate whose wires have been replaced by their indices. A NOT
+// gate reads its input through in1 and in2 alike.
+type simGate struct {
+	operation     string
+	in1, in2, out int
+}
+
+// Simulator evaluates a circuit whose wires are numbered and whose gates are
+// sorted so that every gate comes after the gates driving its inputs. After
+// the first run only the gates downstream of a changed input are evaluated
+// again, so the same circuit can be run cheaply on many inputs.
+type Simulator struct {
+	wires  []string       // Wire names by index
+	index  map[string]int // Wire indices by name
+	gates  []simGate      // Gates in topological order
+	fanout [][]int        // Positions of the gates reading each wire
+	driven []bool         // Whether a gate drives each wire
+	values []int
+	known  []bool // Whether each wire has a value
+	dirty  []bool // Gates to evaluate on the next run
+	next   int    // Position of the first dirty gate
+}
+
+// NewSimulator prepares a circuit for evaluation, taking its current input
+// values as the initial inputs. It fails with ErrCycle if the gates form a
+// loop and with ErrMultipleDrivers if two gates drive the same wire.
+func NewSimulator(c *Circuit) (*Simulator, error) {
+	s := &Simulator{wires: c.wires(), index: make(map[string]int)}
+	for i, wire := range s.wires {
+		s.index[wire] = i
+	}
+	n := len(s.wires)
+	s.fanout = make([][]int, n)
+	s.driven = make([]bool, n)
+	s.values = make([]int, n)
+	s.known = make([]bool, n)
+
+	// Order the gates with Kahn's algorithm, counting for each gate the
+	// inputs that are still waiting for the gate driving them
+	readers := make([][]int, n)
+	for i, gate := range c.gates {
+		if s.driven[s.index[gate.output]] {
+			return nil, fmt.Errorf("%w: %s", ErrMultipleDrivers, gate.output)
+		}
+		s.driven[s.index[gate.output]] = true
+		for _, input := range gate.inputs() {
+			readers[s.index[input]] = append(readers[s.index[input]], i)
+		}
+	}
+	waiting := make([]int, len(c.gates))
+	var ready []int
+	for i, gate := range c.gates {
+		for _, input := range gate.inputs() {
+			if s.driven[s.index[input]] {
+				waiting[i]++
+			}
+		}
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	order := make([]int, 0, len(c.gates))
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		order = append(order, i)
+		for _, reader := range readers[s.index[c.gates[i].output]] {
+			if waiting[reader]--; waiting[reader] == 0 {
+				ready = append(ready, reader)
+			}
+		}
+	}
+	if len(order) < len(c.gates) {
+		var stuck []string
+		for i, gate := range c.gates {
+			if waiting[i] > 0 {
+				stuck = append(stuck, gate.output)
+			}
+		}
+		sort.Strings(stuck)
+		return nil, fmt.Errorf("%w through %s", ErrCycle, strings.Join(stuck, ","))
+	}
+
+	s.gates = make([]simGate, len(order))
+	for pos, i := range order {
+		gate := c.gates[i]
+		inputs := gate.inputs()
+		s.gates[pos] = simGate{gate.operation, s.index[inputs[0]], s.index[inputs[len(inputs)-1]], s.index[gate.output]}
+		for _, input := range inputs {
+			s.fanout[s.index[input]] = append(s.fanout[s.index[input]], pos)
+		}
+	}
+	s.dirty = make([]bool, len(s.gates))
+	for pos := range s.dirty {
+		s.dirty[pos] = true
+	}
+
+	for wire, value := range c.wireValues {
+		if !s.driven[s.index[wire]] {
+			if err := s.Set(wire, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// Set assigns a value to an input wire, one that no gate drives
+func (s *Simulator) Set(wire string, value int) error {
+	i, ok := s.index[wire]
+	switch {
+	case !ok:
+		return fmt.Errorf("unknown wire %s", wire)
+	case s.driven[i]:
+		return fmt.Errorf("wire %s is driven by a gate", wire)
+	case value != 0 && value != 1:
+		return fmt.Errorf("invalid value %d for wire %s", value, wire)
+	}
+
+	if !s.known[i] || s.values[i] != value {
+		s.values[i], s.known[i] = value, true
+		s.markReaders(i)
+	}
+	return nil
+}
+
+// SetNumber assigns a number to the input wires with a prefix, bit i going
+// to the wire named prefix followed by i as two digits
+func (s *Simulator) SetNumber(prefix string, n *big.Int) error {
+	if n.Sign() < 0 {
+		return fmt.Errorf("cannot assign negative number %s to %s wires", n, prefix)
+	}
+
+	width := 0
+	for ; ; width++ {
+		if _, ok := s.index[wireName(prefix, width)]; !ok {
+			break
+		}
+	}
+	if n.BitLen() > width {
+		return fmt.Errorf("%s does not fit in the %d %s wires", n, width, prefix)
+	}
+
+	for i := 0; i < width; i++ {
+		if err := s.Set(wireName(prefix, i), int(n.Bit(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markReaders schedules the gates reading a wire for evaluation
+func (s *Simulator) markReaders(wire int) {
+	for _, pos := range s.fanout[wire] {
+		s.dirty[pos] = true
+		s.next = min(s.next, pos)
+	}
+}
+
+// Run evaluates every gate affected by the inputs assigned since the last
+// run. It fails with ErrUndriven if a gate reads an input that has no value.
+func (s *Simulator) Run() error {
+	for pos := s.next; pos < len(s.gates); pos++ {
+		if !s.dirty[pos] {
+			continue
+		}
+
+		gate := s.gates[pos]
+		for _, input := range []int{gate.in1, gate.in2} {
+			if !s.known[input] {
+				s.next = pos
+				return fmt.Errorf("%w: %s", ErrUndriven, s.wires[input])
+			}
+		}
+
+		value := evaluate(gate.operation, s.values[gate.in1], s.values[gate.in2])
+		s.dirty[pos] = false
+		if !s.known[gate.out] || s.values[gate.out] != value {
+			s.values[gate.out], s.known[gate.out] = value, true
+			s.markReaders(gate.out)
+		}
+	}
+	s.next = len(s.gates)
+	return nil
+}
+
+// Value returns the value of a wire, or false if it has none yet
+func (s *Simulator) Value(wire string) (int, bool) {
+	i, ok := s.index[wire]
+	if !ok || !s.known[i] {
+		return 0, false
+	}
+	return s.values[i], true
+}
+
+// Output reads the number on the wires with a prefix, bit i coming from the
+// wire named prefix followed by i as two digits. It fails with ErrUndriven
+// if one of those wires has no value.
+func (s *Simulator) Output(prefix string) (*big.Int, error) {
+	n := new(big.Int)
+	for i := 0; ; i++ {
+		wire := wireName(prefix, i)
+		if _, ok := s.index[wire]; !ok {
+			return n, nil
+		}
+
+		value, ok := s.Value(wire)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUndriven, wire)
+		}
+		n.SetBit(n, i, uint(value))
+	}
+}
@@ -1,22 +1,30 @@
-package main
+package day24
 
 import (
 	"bufio"
+	_ "embed"
 	"fmt"
-	"log"
-	"os"
+	"io"
+	"math/big"
+	"math/rand"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"aoc2024/aoc"
 )
 
 // represents a digital logic circuit with gates and wires
 type Circuit struct {
 	wireValues map[string]int
 	gates      []Gate // Changed from map[string][]Gate to []Gate
+	lastOutput string // Most significant z wire, the adder's final carry
 }
 
-// represents a logic gate with its connections
+// represents a logic gate with its connections. NOT gates leave input2
+// empty.
 type Gate struct {
 	input1    string
 	operation string
@@ -24,142 +32,205 @@ type Gate struct {
 	output    string
 }
 
-// creates a new Circuit from input lines
-func NewCircuit(input []string) *Circuit {
-	wireValues, gates := parseInput(input)
+// operations gives the number of inputs each kind of gate takes
+var operations = map[string]int{
+	"AND":  2,
+	"OR":   2,
+	"XOR":  2,
+	"NAND": 2,
+	"NOR":  2,
+	"XNOR": 2,
+	"NOT":  1,
+}
+
+// inputs returns the wires the gate reads
+func (g Gate) inputs() []string {
+	if g.input2 == "" {
+		return []string{g.input1}
+	}
+	return []string{g.input1, g.input2}
+}
+
+// evaluate applies an operation to the values of a gate's inputs. The
+// second value is ignored by NOT.
+func evaluate(operation string, a, b int) int {
+	switch operation {
+	case "AND":
+		return a & b
+	case "OR":
+		return a | b
+	case "XOR":
+		return a ^ b
+	case "NAND":
+		return 1 ^ a&b
+	case "NOR":
+		return 1 ^ (a | b)
+	case "XNOR":
+		return 1 ^ a ^ b
+	case "NOT":
+		return 1 ^ a
+	}
+	panic("unknown operation " + operation)
+}
+
+// creates a new Circuit from input lines, failing on the first malformed
+// line
+func NewCircuit(input []string) (*Circuit, error) {
+	wireValues, gates, err := parseInput(input)
+	if err != nil {
+		return nil, err
+	}
 	return &Circuit{
 		wireValues: wireValues,
 		gates:      gates,
+		lastOutput: lastOutputWire(gates),
+	}, nil
+}
+
+// lastOutputWire returns the most significant z wire driven by a gate
+func lastOutputWire(gates []Gate) string {
+	last := ""
+	for _, gate := range gates {
+		if strings.HasPrefix(gate.output, "z") && gate.output > last {
+			last = gate.output
+		}
 	}
+	return last
 }
 
 // splits the input into initial wire values and gate connections
-func parseInput(input []string) (map[string]int, []Gate) {
+func parseInput(input []string) (map[string]int, []Gate, error) {
 	wireValues := make(map[string]int)
 	var gates []Gate
 
 	isInitialState := true
-	for _, line := range input {
+	for i, line := range input {
 		if line == "" {
 			isInitialState = false
 			continue
 		}
 
 		if isInitialState {
-			wire, value := parseWireValue(line)
+			wire, value, err := parseWireValue(line)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if _, exists := wireValues[wire]; exists {
+				return nil, nil, fmt.Errorf("line %d: wire %s is given a value twice", i+1, wire)
+			}
 			wireValues[wire] = value
 			continue
 		}
 
-		if len(line) > 3 {
-			gate := parseGate(line)
-			gates = append(gates, gate)
+		gate, err := parseGate(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", i+1, err)
 		}
+		gates = append(gates, gate)
 	}
 
-	return wireValues, gates
+	return wireValues, gates, nil
 }
 
-// extracts wire name and value from a line like "x00: 1"
-func parseWireValue(line string) (string, int) {
-	parts := strings.Split(line, ":")
-	wire := strings.TrimSpace(parts[0])
-	value, _ := strconv.Atoi(strings.TrimSpace(parts[1]))
-	return wire, value
+// isWireName reports whether s can name a wire
+func isWireName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
 }
 
-// creates a Gate from a line like "x00 AND y00 -> z00"
-func parseGate(line string) Gate {
-	parts := strings.Split(line, " ")
-	return Gate{
-		input1:    parts[0],
-		operation: parts[1],
-		input2:    parts[2],
-		output:    parts[4],
+// extracts wire name and value from a line like "x00: 1"
+func parseWireValue(line string) (string, int, error) {
+	wire, text, found := strings.Cut(line, ":")
+	wire, text = strings.TrimSpace(wire), strings.TrimSpace(text)
+	if !found || !isWireName(wire) {
+		return "", 0, fmt.Errorf("expected \"<wire>: <value>\", got %q", line)
 	}
-}
 
-// evaluates all gates in the circuit until all outputs are stable
-func (c *Circuit) Simulate() {
-	queue := make([]string, 0, len(c.wireValues))
-	for wire := range c.wireValues {
-		queue = append(queue, wire)
+	value, err := strconv.Atoi(text)
+	if err != nil || (value != 0 && value != 1) {
+		return "", 0, fmt.Errorf("wire %s has value %q, want 0 or 1", wire, text)
 	}
+	return wire, value, nil
+}
 
-	evaluated := make(map[Gate]bool)
-	visited := make(map[string]bool)
+// creates a Gate from a line like "x00 AND y00 -> z00" or "NOT x00 -> z00"
+func parseGate(line string) (Gate, error) {
+	parts := strings.Fields(line)
+	var gate Gate
+	switch {
+	case len(parts) == 4 && parts[2] == "->":
+		gate = Gate{operation: parts[0], input1: parts[1], output: parts[3]}
+	case len(parts) == 5 && parts[3] == "->":
+		gate = Gate{input1: parts[0], operation: parts[1], input2: parts[2], output: parts[4]}
+	default:
+		return Gate{}, fmt.Errorf("expected \"<wire> <op> <wire> -> <wire>\" or \"NOT <wire> -> <wire>\", got %q", line)
+	}
 
-	for len(queue) > 0 {
-		wire := queue[0]
-		queue = queue[1:]
+	if err := gate.check(); err != nil {
+		return Gate{}, err
+	}
+	return gate, nil
+}
 
-		if visited[wire] || !c.hasValue(wire) {
-			continue
+// check reports an unknown operation, a wrong number of inputs or an
+// invalid wire name
+func (g Gate) check() error {
+	arity, ok := operations[g.operation]
+	if !ok {
+		return fmt.Errorf("unknown operation %q", g.operation)
+	}
+	if len(g.inputs()) != arity {
+		noun := "inputs"
+		if arity == 1 {
+			noun = "input"
 		}
-
-		// Find gates that use this wire as input
-		for _, gate := range c.gates {
-			if (gate.input1 == wire || gate.input2 == wire) && !evaluated[gate] {
-				if c.evaluateGate(gate) {
-					queue = append(queue, gate.output)
-					evaluated[gate] = true
-				}
-			}
+		return fmt.Errorf("%s takes %d %s, got %d", g.operation, arity, noun, len(g.inputs()))
+	}
+	for _, wire := range append(g.inputs(), g.output) {
+		if !isWireName(wire) {
+			return fmt.Errorf("invalid wire name %q", wire)
 		}
-
-		visited[wire] = true
 	}
+	return nil
 }
 
-// checks if a wire has a value assigned
-func (c *Circuit) hasValue(wire string) bool {
-	_, exists := c.wireValues[wire]
-	return exists
-}
-
-// applies the gate operation if both inputs are available
-func (c *Circuit) evaluateGate(gate Gate) bool {
-	input1Val, hasInput1 := c.wireValues[gate.input1]
-	input2Val, hasInput2 := c.wireValues[gate.input2]
-
-	if !hasInput1 || !hasInput2 {
-		return false
+// evaluates all gates in the circuit and records the value of every wire
+func (c *Circuit) Simulate() error {
+	sim, err := NewSimulator(c)
+	if err != nil {
+		return err
 	}
-
-	switch gate.operation {
-	case "AND":
-		c.wireValues[gate.output] = input1Val & input2Val
-	case "OR":
-		c.wireValues[gate.output] = input1Val | input2Val
-	case "XOR":
-		c.wireValues[gate.output] = input1Val ^ input2Val
+	if err := sim.Run(); err != nil {
+		return err
 	}
-	return true
-}
 
-// converts binary outputs starting with prefix to decimal
-func (c *Circuit) GetDecimalOutput(prefix string) int64 {
-	wires := c.getWiresWithPrefix(prefix)
-	sort.Strings(wires)
-
-	binary := make([]string, len(wires))
-	for i := len(wires) - 1; i >= 0; i-- {
-		binary = append(binary, fmt.Sprintf("%d", c.wireValues[wires[i]]))
+	for i, wire := range sim.wires {
+		if sim.known[i] {
+			c.wireValues[wire] = sim.values[i]
+		}
 	}
-
-	result, _ := strconv.ParseInt(strings.Join(binary, ""), 2, 64)
-	return result
+	return nil
 }
 
-// returns all wire names starting with the given prefix
-func (c *Circuit) getWiresWithPrefix(prefix string) []string {
-	var wires []string
-	for wire := range c.wireValues {
-		if strings.HasPrefix(wire, prefix) {
-			wires = append(wires, wire)
+// converts binary outputs starting with prefix to a number, bit i coming
+// from the wire named prefix followed by i as two digits, as in
+// Simulator.Output. Bits stop at the first wire with no value.
+func (c *Circuit) GetDecimalOutput(prefix string) *big.Int {
+	result := new(big.Int)
+	for i := 0; ; i++ {
+		value, ok := c.wireValues[wireName(prefix, i)]
+		if !ok {
+			return result
 		}
+		result.SetBit(result, i, uint(value))
 	}
-	return wires
 }
 
 // checks if the circuit follows adder rules
@@ -181,7 +252,7 @@ func (c *Circuit) ValidateRippleCarryAdder() string {
 // checks if a gate violates ripple-carry adder rules
 func (c *Circuit) isGateFaulty(gate Gate) bool {
 	// Rule 1: z-output must use XOR except for last bit
-	if strings.HasPrefix(gate.output, "z") && gate.output != "z45" && gate.operation != "XOR" {
+	if strings.HasPrefix(gate.output, "z") && gate.output != c.lastOutput && gate.operation != "XOR" {
 		return true
 	}
 
@@ -229,44 +300,101 @@ func isFirstInputPair(wire1, wire2 string) bool {
 // hasConsumerGate checks if a wire is used as input to a gate with the specified operation
 func (c *Circuit) hasConsumerGate(wire, operation string) bool {
 	for _, gate := range c.gates {
-		if (gate.input1 == wire || gate.input2 == wire) && gate.operation == operation {
+		if slices.Contains(gate.inputs(), wire) && gate.operation == operation {
 			return true
 		}
 	}
 	return false
 }
 
-func main() {
-	input := readLines("input.txt")
-	circuit := NewCircuit(input)
+// reads all lines of the puzzle input
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	buf := make([]byte, 512*1024)
+	scanner.Buffer(buf, 512*1024)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+//go:embed input.txt
+var input string
+
+// GraphPath names a file that Parse writes a graph of the circuit to when
+// set, in Mermaid format for .mmd or .mermaid files and Graphviz DOT
+// otherwise
+var GraphPath string
+
+// SearchSwaps makes Part2 find the swapped wires by searching for the swaps
+// that repair the adder instead of by checking the adder's wiring rules
+var SearchSwaps bool
 
-	circuit.Simulate()
-	part1 := circuit.GetDecimalOutput("z")
-	fmt.Printf("Part 1: %d\n", part1)
+func init() {
+	aoc.Register(2024, 24, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 24, input)
+}
 
-	part2 := circuit.ValidateRippleCarryAdder()
-	fmt.Printf("Part 2: %s\n", part2)
+// Solver solves day 24 using the initial wire values and gate connections
+type Solver struct {
+	circuit *Circuit
 }
 
-func readLines(path string) []string {
-	file, err := os.Open(path)
+// Parse reads the lines describing the circuit and checks that it can be
+// simulated
+func (s *Solver) Parse(r io.Reader) error {
+	input, err := readLines(r)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	defer file.Close()
 
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	buf := make([]byte, 512*1024)
-	scanner.Buffer(buf, 512*1024)
+	circuit, err := NewCircuit(input)
+	if err != nil {
+		return err
+	}
+	if err := circuit.Validate(); err != nil {
+		return err
+	}
 
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	s.circuit = circuit
+	if GraphPath != "" {
+		return circuit.writeGraphFile(GraphPath)
 	}
+	return nil
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+// Part1 returns the decimal number output on the z wires
+func (s *Solver) Part1() (aoc.Answer, error) {
+	// Simulating sets wire values, so start from a fresh circuit
+	circuit := s.circuit.Clone()
+	if err := circuit.Simulate(); err != nil {
+		return aoc.Answer{}, err
+	}
+
+	output := circuit.GetDecimalOutput("z")
+	if !output.IsInt64() {
+		return aoc.Text(output.String()), nil
+	}
+	return aoc.Int(output.Int64()), nil
+}
+
+// Part2 returns the sorted names of the wires involved in a swap
+func (s *Solver) Part2() (aoc.Answer, error) {
+	circuit := s.circuit
+	if !SearchSwaps {
+		return aoc.Text(circuit.ValidateRippleCarryAdder()), nil
 	}
 
-	return lines
+	swaps, err := circuit.Repair(4, rand.New(rand.NewSource(1)))
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.Text(SwappedWires(swaps)), nil
 }
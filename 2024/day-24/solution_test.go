@@ -1,8 +1,14 @@
-package main
+package day24
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
 	"strings"
 	"testing"
+
+	"aoc2024/aoc"
 )
 
 func TestSmallExample(t *testing.T) {
@@ -17,12 +23,14 @@ x00 AND y00 -> z00
 x01 XOR y01 -> z01
 x02 OR y02 -> z02`
 
-	circuit := NewCircuit(strings.Split(input, "\n"))
-	circuit.Simulate()
+	circuit := mustCircuit(t, strings.Split(input, "\n"))
+	if err := circuit.Simulate(); err != nil {
+		t.Fatal(err)
+	}
 	result := circuit.GetDecimalOutput("z")
 
-	expected := int64(4) // Binary 100
-	if result != expected {
+	expected := big.NewInt(4) // Binary 100
+	if result.Cmp(expected) != 0 {
 		t.Errorf("Small example failed: got %d, want %d", result, expected)
 	}
 }
@@ -76,12 +84,14 @@ hwm AND bqk -> z03
 tgd XOR rvg -> z12
 tnw OR pbm -> gnj`
 
-	circuit := NewCircuit(strings.Split(input, "\n"))
-	circuit.Simulate()
+	circuit := mustCircuit(t, strings.Split(input, "\n"))
+	if err := circuit.Simulate(); err != nil {
+		t.Fatal(err)
+	}
 	result := circuit.GetDecimalOutput("z")
 
-	expected := int64(2024) // Binary 0011111101000
-	if result != expected {
+	expected := big.NewInt(2024) // Binary 0011111101000
+	if result.Cmp(expected) != 0 {
 		t.Errorf("Larger example failed: got %d, want %d", result, expected)
 	}
 }
@@ -145,13 +155,353 @@ func TestParseGate(t *testing.T) {
 			"x02 OR y02 -> z02",
 			Gate{input1: "x02", operation: "OR", input2: "y02", output: "z02"},
 		},
+		{
+			"x03 NAND y03 -> z03",
+			Gate{input1: "x03", operation: "NAND", input2: "y03", output: "z03"},
+		},
+		{
+			"NOT x04 -> z04",
+			Gate{input1: "x04", operation: "NOT", output: "z04"},
+		},
 	}
 
 	for _, test := range tests {
-		got := parseGate(test.input)
-		if got != test.want {
+		got, err := parseGate(test.input)
+		if err != nil {
+			t.Errorf("parseGate(%q) failed: %v", test.input, err)
+		} else if got != test.want {
 			t.Errorf("parseGate(%q) = %v, want %v", test.input, got, test.want)
 		}
 	}
 }
 
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing colon", "x00 1\n\nx00 AND x00 -> z00", "line 1: expected"},
+		{"bad value", "x00: 1\ny00: 2\n\nx00 AND y00 -> z00", "line 2: wire y00 has value \"2\""},
+		{"repeated value", "x00: 1\nx00: 0\n\nNOT x00 -> z00", "line 2: wire x00 is given a value twice"},
+		{"wrong arity", "x00: 1\n\nx00 NOT x00 -> z00", "line 3: NOT takes 1 input, got 2"},
+		{"unknown operation", "x00: 1\n\nx00 ADD x00 -> z00", "line 3: unknown operation \"ADD\""},
+		{"missing arrow", "x00: 1\n\nx00 AND x00 z00", "line 3: expected"},
+		{"short line", "x00: 1\n\nz00", "line 3: expected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCircuit(strings.Split(tt.input, "\n"))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("got error %v, want one starting with %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGateLibrary(t *testing.T) {
+	input := `x00: 1
+y00: 0
+
+x00 NAND y00 -> z00
+x00 NOR y00 -> z01
+x00 XNOR y00 -> z02
+NOT y00 -> z03
+x00 AND y00 -> z04`
+
+	circuit := mustCircuit(t, strings.Split(input, "\n"))
+	if err := circuit.Simulate(); err != nil {
+		t.Fatal(err)
+	}
+	// z00..z04 = 1, 0, 0, 1, 0
+	if got := circuit.GetDecimalOutput("z"); got.Cmp(big.NewInt(0b01001)) != 0 {
+		t.Errorf("got output %b, want 1001", got)
+	}
+}
+
+func TestWideOutput(t *testing.T) {
+	// z_i = x_i OR y_i over 101 bits, with x11 and x100 set
+	var values, gates []string
+	for i := 0; i <= 100; i++ {
+		x := 0
+		if i == 11 || i == 100 {
+			x = 1
+		}
+		values = append(values, fmt.Sprintf("%s: %d", wireName("x", i), x), fmt.Sprintf("%s: 0", wireName("y", i)))
+		gates = append(gates, fmt.Sprintf("%s OR %s -> %s", wireName("x", i), wireName("y", i), wireName("z", i)))
+	}
+	lines := append(append(values, ""), gates...)
+
+	want := new(big.Int).SetBit(new(big.Int).SetBit(new(big.Int), 11, 1), 100, 1)
+
+	circuit := mustCircuit(t, lines)
+	if err := circuit.Simulate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := circuit.GetDecimalOutput("z"); got.Cmp(want) != 0 {
+		t.Errorf("GetDecimalOutput() = %v, want %v", got, want)
+	}
+
+	s := &Solver{}
+	if err := s.Parse(strings.NewReader(strings.Join(lines, "\n"))); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Part1(); err != nil || got != aoc.Text(want.String()) {
+		t.Errorf("Part1() = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []error
+	}{
+		{
+			name:  "valid",
+			input: "x00: 1\ny00: 0\n\nx00 XOR y00 -> z00\nx00 AND y00 -> z01",
+		},
+		{
+			name:  "two drivers",
+			input: "x00: 1\ny00: 0\n\nx00 XOR y00 -> z00\nx00 AND y00 -> z00",
+			want:  []error{ErrMultipleDrivers},
+		},
+		{
+			name:  "undriven and driven input",
+			input: "x00: 1\nz00: 0\n\nx00 XOR y00 -> z00",
+			want:  []error{ErrUndriven},
+		},
+		{
+			name:  "cycle",
+			input: "x00: 1\n\nx00 AND b -> a\nNOT a -> b\na XOR b -> z00",
+			want:  []error{ErrCycle},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mustCircuit(t, strings.Split(tt.input, "\n")).Validate()
+			if tt.want == nil && err != nil {
+				t.Errorf("got error %v, want none", err)
+			}
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf("got error %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExportGraph(t *testing.T) {
+	input := `x00: 1
+y00: 0
+
+x00 XOR y00 -> z00
+x00 AND y00 -> z01
+x00 OR y00 -> z02`
+
+	circuit := mustCircuit(t, strings.Split(input, "\n"))
+
+	var dot strings.Builder
+	if err := circuit.ExportGraph(&dot, "adder.dot"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"digraph circuit {",
+		`g0 [shape=box, style=filled, fillcolor="#fdd0a2", label="XOR"];`,
+		`"x00" -> g0;`,
+		`g0 -> "z00";`,
+		// Only the last output bit, z02, may come from a gate other than XOR
+		`g1 [shape=box, style=filled, fillcolor="#9ecae1", label="AND", color="#e31a1c", penwidth=3];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output is missing %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid strings.Builder
+	if err := circuit.ExportGraph(&mermaid, "adder.mmd"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"flowchart LR",
+		"w_x00([x00])",
+		"g0[XOR]:::xor",
+		"w_y00 --> g0",
+		"g0 --> w_z00",
+		"class g1 faulty",
+		"classDef xor fill:#fdd0a2",
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid output is missing %q:\n%s", want, mermaid.String())
+		}
+	}
+}
+
+// mustCircuit parses a circuit, failing the test if it is malformed
+func mustCircuit(t *testing.T, lines []string) *Circuit {
+	t.Helper()
+	circuit, err := NewCircuit(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return circuit
+}
+
+// rippleCarryAdder returns the input of a working adder of the given width
+// with every input wire set to 0
+func rippleCarryAdder(bits int) []string {
+	var lines []string
+	for _, prefix := range []string{"x", "y"} {
+		for i := 0; i < bits; i++ {
+			lines = append(lines, fmt.Sprintf("%s%02d: 0", prefix, i))
+		}
+	}
+	lines = append(lines, "", "x00 XOR y00 -> z00", "x00 AND y00 -> c00")
+	for i := 1; i < bits; i++ {
+		carry := fmt.Sprintf("c%02d", i)
+		if i == bits-1 {
+			carry = fmt.Sprintf("z%02d", bits)
+		}
+		lines = append(lines,
+			fmt.Sprintf("x%02d XOR y%02d -> a%02d", i, i, i),
+			fmt.Sprintf("x%02d AND y%02d -> b%02d", i, i, i),
+			fmt.Sprintf("a%02d XOR c%02d -> z%02d", i, i-1, i),
+			fmt.Sprintf("a%02d AND c%02d -> d%02d", i, i-1, i),
+			fmt.Sprintf("b%02d OR d%02d -> %s", i, i, carry),
+		)
+	}
+	return lines
+}
+
+func TestRepair(t *testing.T) {
+	tests := []struct {
+		name  string
+		bits  int
+		swaps []Swap
+		want  string
+	}{
+		{"working adder", 6, nil, ""},
+		{"one swap", 6, []Swap{{"a03", "b03"}}, "a03,b03"},
+		{"two swaps", 10, []Swap{{"b02", "z02"}, {"c06", "z06"}}, "b02,c06,z02,z06"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			circuit := mustCircuit(t, rippleCarryAdder(tt.bits))
+			for _, swap := range tt.swaps {
+				if err := circuit.SwapOutputs(swap[0], swap[1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			rng := rand.New(rand.NewSource(1))
+			swaps, err := circuit.Repair(len(tt.swaps), rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := SwappedWires(swaps); got != tt.want {
+				t.Errorf("got swaps %s, want %s", got, tt.want)
+			}
+			if len(tt.swaps) > 0 && circuit.VerifyAdder(rng) {
+				t.Error("Repair changed the circuit it was given")
+			}
+		})
+	}
+}
+
+func TestRepairFails(t *testing.T) {
+	circuit := mustCircuit(t, rippleCarryAdder(6))
+	if err := circuit.SwapOutputs("a02", "b02"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := circuit.Repair(0, rand.New(rand.NewSource(1))); !errors.Is(err, ErrNoRepair) {
+		t.Errorf("got error %v, want ErrNoRepair", err)
+	}
+	if err := circuit.SwapOutputs("a02", "x02"); err == nil {
+		t.Error("swapping an input wire succeeded")
+	}
+}
+
+func TestSimulator(t *testing.T) {
+	// Wide enough that the sum no longer fits in 64 bits
+	sim, err := NewSimulator(mustCircuit(t, rippleCarryAdder(70)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, _ := new(big.Int).SetString("1180591620717411303423", 10) // 2^70 - 1
+	for _, y := range []*big.Int{big.NewInt(1), big.NewInt(0), x} {
+		if err := sim.SetNumber("x", x); err != nil {
+			t.Fatal(err)
+		}
+		if err := sim.SetNumber("y", y); err != nil {
+			t.Fatal(err)
+		}
+		if err := sim.Run(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := sim.Output("z")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := new(big.Int).Add(x, y); got.Cmp(want) != 0 {
+			t.Errorf("%s + %s: got %s, want %s", x, y, got, want)
+		}
+	}
+
+	if err := sim.SetNumber("x", new(big.Int).Lsh(x, 1)); err == nil {
+		t.Error("assigning a number wider than the inputs succeeded")
+	}
+	if err := sim.Set("z00", 1); err == nil {
+		t.Error("assigning a gate output succeeded")
+	}
+}
+
+func TestSimulatorErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{
+			name: "cycle",
+			input: `x00: 1
+
+x00 AND b -> a
+a OR x00 -> b
+a XOR b -> z00`,
+			want: ErrCycle,
+		},
+		{
+			name: "undriven wire",
+			input: `x00: 1
+
+x00 AND y00 -> z00`,
+			want: ErrUndriven,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mustCircuit(t, strings.Split(tt.input, "\n")).Simulate()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepairPuzzleInput(t *testing.T) {
+	circuit := mustCircuit(t, strings.Split(input, "\n"))
+	swaps, err := circuit.Repair(4, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := SwappedWires(swaps), circuit.ValidateRippleCarryAdder(); got != want {
+		t.Errorf("got swaps %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package day24
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// String returns the gate as written in the puzzle input
+func (g Gate) String() string {
+	if g.input2 == "" {
+		return fmt.Sprintf("%s %s -> %s", g.operation, g.input1, g.output)
+	}
+	return fmt.Sprintf("%s %s %s -> %s", g.input1, g.operation, g.input2, g.output)
+}
+
+// Validate checks that the circuit can be simulated: every gate must have
+// a known operation with the right number of inputs, no wire may be driven
+// by two gates or by a gate and an initial value, every wire a gate reads
+// must be driven or given a value, and the gates must not form a loop.
+// Every problem found is reported. The check is meant for a circuit as
+// parsed, since Simulate leaves values on the driven wires.
+func (c *Circuit) Validate() error {
+	var errs []error
+
+	drivers := make(map[string][]Gate)
+	for _, gate := range c.gates {
+		if err := gate.check(); err != nil {
+			errs = append(errs, fmt.Errorf("gate %s: %w", gate, err))
+		}
+		drivers[gate.output] = append(drivers[gate.output], gate)
+	}
+
+	for _, wire := range c.wires() {
+		gates := drivers[wire]
+		if len(gates) > 1 {
+			var names []string
+			for _, gate := range gates {
+				names = append(names, gate.String())
+			}
+			errs = append(errs, fmt.Errorf("%w: %s by %s", ErrMultipleDrivers, wire, strings.Join(names, " and ")))
+		}
+
+		_, hasValue := c.wireValues[wire]
+		switch {
+		case len(gates) > 0 && hasValue:
+			errs = append(errs, fmt.Errorf("wire %s is given a value but driven by %s", wire, gates[0]))
+		case len(gates) == 0 && !hasValue:
+			errs = append(errs, fmt.Errorf("%w: %s is read but neither driven nor given a value", ErrUndriven, wire))
+		}
+	}
+
+	// The simulator reports loops, but only once each wire has one driver
+	if len(errs) == 0 {
+		if _, err := NewSimulator(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -1,10 +1,11 @@
-package main
+package day25
 
 import (
 	"bufio"
-	"fmt"
+	_ "embed"
 	"io"
-	"os"
+
+	"aoc2024/aoc"
 )
 
 const (
@@ -22,23 +23,39 @@ type Schematic struct {
 	isLock  bool
 }
 
-func main() {
-	// Open the file
-	file, err := os.Open("input.txt")
+//go:embed input.txt
+var input string
+
+func init() {
+	aoc.Register(2024, 25, func() aoc.Solver { return &Solver{} })
+	aoc.Embed(2024, 25, input)
+}
+
+// Solver solves day 25 using the lock and key schematics
+type Solver struct {
+	locks, keys []PinHeights
+}
+
+// Parse reads the lock and key schematics
+func (s *Solver) Parse(r io.Reader) error {
+	locks, keys, err := parseFile(r)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		return err
 	}
-	defer file.Close()
 
-	locks, keys, error := parseFile(file)
-	if error != nil {
-		fmt.Println("Error parsing file:", error)
-		return
-	}
+	s.locks = locks
+	s.keys = keys
+	return nil
+}
 
-	compatiblePairs := countCompatiblePairs(locks, keys)
-	fmt.Println(compatiblePairs)
+// Part1 returns the number of lock and key pairs that fit together
+func (s *Solver) Part1() (aoc.Answer, error) {
+	return aoc.Int(countCompatiblePairs(s.locks, s.keys)), nil
+}
+
+// Part2 has no puzzle; the final star is awarded for the other 49
+func (s *Solver) Part2() (aoc.Answer, error) {
+	return aoc.Answer{}, aoc.ErrNoPart
 }
 
 // counts how many lock-key pairs can work together
@@ -131,4 +148,3 @@ func calculatePinHeights(rows [schematicRows]string) PinHeights {
 
 	return heights
 }
-
@@ -0,0 +1,3 @@
+module aoc2024
+
+go 1.23.1
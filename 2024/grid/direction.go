@@ -0,0 +1,88 @@
+package grid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Direction is one of the four cardinal directions, numbered clockwise so
+// that turning is a matter of modular arithmetic.
+type Direction int
+
+const (
+	North Direction = iota
+	East
+	South
+	West
+)
+
+// Directions holds the four cardinal directions, clockwise from North
+var Directions = [4]Direction{North, East, South, West}
+
+// Delta returns the offset of a single step in the direction
+func (d Direction) Delta() Point {
+	return Offsets4[d]
+}
+
+// Clockwise returns the direction after a 90° turn to the right
+func (d Direction) Clockwise() Direction {
+	return (d + 1) % 4
+}
+
+// CounterClockwise returns the direction after a 90° turn to the left
+func (d Direction) CounterClockwise() Direction {
+	return (d + 3) % 4
+}
+
+// Reverse returns the opposite direction
+func (d Direction) Reverse() Direction {
+	return (d + 2) % 4
+}
+
+// String returns the name of the direction
+func (d Direction) String() string {
+	switch d {
+	case North:
+		return "North"
+	case East:
+		return "East"
+	case South:
+		return "South"
+	case West:
+		return "West"
+	}
+	return "Direction(?)"
+}
+
+// ParseDirection reads a direction given by name, by its initial or by one
+// of the arrows ^ > v <, ignoring case
+func ParseDirection(s string) (Direction, error) {
+	if len(s) == 1 {
+		if d, ok := DirectionFromArrow(s[0]); ok {
+			return d, nil
+		}
+	}
+	for _, d := range Directions {
+		name := d.String()
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:1]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown direction %q", s)
+}
+
+// DirectionFromArrow converts one of the arrows ^ > v < used by the puzzles
+// into a direction.
+func DirectionFromArrow(arrow byte) (Direction, bool) {
+	switch arrow {
+	case '^':
+		return North, true
+	case '>':
+		return East, true
+	case 'v':
+		return South, true
+	case '<':
+		return West, true
+	}
+	return 0, false
+}
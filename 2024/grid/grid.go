@@ -0,0 +1,210 @@
+package grid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Grid is a rectangular map of cells addressed by Point
+type Grid[T any] struct {
+	width, height int
+	cells         []T
+}
+
+// New returns a grid of the given size with every cell set to its zero value
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// FromRows builds a grid from a slice of rows, which must all have the same
+// length.
+func FromRows[T any](rows [][]T) (*Grid[T], error) {
+	if len(rows) == 0 {
+		return New[T](0, 0), nil
+	}
+
+	g := New[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		if len(row) != g.width {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", y+1, len(row), g.width)
+		}
+		copy(g.cells[y*g.width:], row)
+	}
+	return g, nil
+}
+
+// FromLines builds a grid from lines of text, converting each character into
+// a cell value.
+func FromLines[T any](lines []string, convert func(rune) (T, error)) (*Grid[T], error) {
+	rows := make([][]T, len(lines))
+	for y, line := range lines {
+		row := make([]T, 0, len(line))
+		for x, r := range []rune(line) {
+			cell, err := convert(r)
+			if err != nil {
+				return nil, fmt.Errorf("line %d, column %d: %w", y+1, x+1, err)
+			}
+			row = append(row, cell)
+		}
+		rows[y] = row
+	}
+	return FromRows(rows)
+}
+
+// Parse reads a grid from r, one row per line. Reading stops at the first
+// blank line, so that a map followed by other sections can be read from the
+// same reader.
+func Parse[T any](r io.Reader, convert func(rune) (T, error)) (*Grid[T], error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading grid: %w", err)
+	}
+	return FromLines(lines, convert)
+}
+
+// AsRune keeps each character of the input as it is
+func AsRune(r rune) (rune, error) {
+	return r, nil
+}
+
+// AsByte keeps each character of the input as a byte
+func AsByte(r rune) (byte, error) {
+	if r > 0xff {
+		return 0, fmt.Errorf("character %q is not a single byte", r)
+	}
+	return byte(r), nil
+}
+
+// AsDigit converts the characters '0' to '9' into their values
+func AsDigit(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, fmt.Errorf("character %q is not a digit", r)
+	}
+	return int(r - '0'), nil
+}
+
+// Width returns the number of columns
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+// InBounds checks if the point lies on the grid
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// At returns the cell at p and panics if p is off the grid
+func (g *Grid[T]) At(p Point) T {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: point %v outside %dx%d grid", p, g.width, g.height))
+	}
+	return g.cells[p.Y*g.width+p.X]
+}
+
+// Get returns the cell at p, or the zero value and false if p is off the grid
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// Set stores a value in the cell at p and panics if p is off the grid
+func (g *Grid[T]) Set(p Point, value T) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: point %v outside %dx%d grid", p, g.width, g.height))
+	}
+	g.cells[p.Y*g.width+p.X] = value
+}
+
+// Clone returns a copy of the grid that can be modified independently
+func (g *Grid[T]) Clone() *Grid[T] {
+	clone := New[T](g.width, g.height)
+	copy(clone.cells, g.cells)
+	return clone
+}
+
+// All iterates over every cell in reading order, row by row
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, cell := range g.cells {
+			if !yield(Point{i % g.width, i / g.width}, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Points iterates over every point of the grid in reading order
+func (g *Grid[T]) Points() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for y := 0; y < g.height; y++ {
+			for x := 0; x < g.width; x++ {
+				if !yield(Point{x, y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbours4 iterates over the orthogonal neighbours of p that lie on the grid
+func (g *Grid[T]) Neighbours4(p Point) iter.Seq[Point] {
+	return g.neighbours(p, Offsets4[:])
+}
+
+// Neighbours8 iterates over all neighbours of p, including diagonals, that
+// lie on the grid
+func (g *Grid[T]) Neighbours8(p Point) iter.Seq[Point] {
+	return g.neighbours(p, Offsets8[:])
+}
+
+func (g *Grid[T]) neighbours(p Point, offsets []Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, offset := range offsets {
+			next := p.Add(offset)
+			if g.InBounds(next) && !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// IndexFunc returns the first point in reading order whose cell satisfies f
+func (g *Grid[T]) IndexFunc(f func(T) bool) (Point, bool) {
+	for p, cell := range g.All() {
+		if f(cell) {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// Rotate returns a copy of the grid turned a quarter turn clockwise
+func (g *Grid[T]) Rotate() *Grid[T] {
+	rotated := New[T](g.height, g.width)
+	for p, cell := range g.All() {
+		rotated.Set(Point{g.height - 1 - p.Y, p.X}, cell)
+	}
+	return rotated
+}
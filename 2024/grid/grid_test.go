@@ -0,0 +1,193 @@
+package grid
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		{
+			name:       "rectangular grid",
+			input:      "abc\ndef\n",
+			wantWidth:  3,
+			wantHeight: 2,
+		},
+		{
+			name:       "stops at blank line",
+			input:      "ab\ncd\n\n<>^v\n",
+			wantWidth:  2,
+			wantHeight: 2,
+		},
+		{
+			name:    "ragged rows",
+			input:   "abc\nde\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Parse(strings.NewReader(tt.input), AsRune)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if g.Width() != tt.wantWidth || g.Height() != tt.wantHeight {
+				t.Errorf("Parse() size = %dx%d, want %dx%d", g.Width(), g.Height(), tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestAsDigit(t *testing.T) {
+	g, err := FromLines([]string{"01", "89"}, AsDigit)
+	if err != nil {
+		t.Fatalf("FromLines() error = %v", err)
+	}
+	if got := g.At(Point{1, 1}); got != 9 {
+		t.Errorf("At(1,1) = %d, want 9", got)
+	}
+
+	if _, err := FromLines([]string{"0."}, AsDigit); err == nil {
+		t.Error("FromLines() with a non-digit did not return an error")
+	}
+}
+
+func TestBounds(t *testing.T) {
+	g := New[int](3, 2)
+	g.Set(Point{2, 1}, 7)
+
+	tests := []struct {
+		point Point
+		want  int
+		ok    bool
+	}{
+		{Point{0, 0}, 0, true},
+		{Point{2, 1}, 7, true},
+		{Point{3, 1}, 0, false},
+		{Point{2, 2}, 0, false},
+		{Point{-1, 0}, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := g.Get(tt.point)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Get(%v) = %d, %v, want %d, %v", tt.point, got, ok, tt.want, tt.ok)
+		}
+		if g.InBounds(tt.point) != tt.ok {
+			t.Errorf("InBounds(%v) = %v, want %v", tt.point, !tt.ok, tt.ok)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("At() off the grid did not panic")
+		}
+	}()
+	g.At(Point{3, 0})
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New[int](3, 3)
+
+	tests := []struct {
+		name string
+		got  []Point
+		want []Point
+	}{
+		{
+			name: "corner, orthogonal",
+			got:  slices.Collect(g.Neighbours4(Point{0, 0})),
+			want: []Point{{1, 0}, {0, 1}},
+		},
+		{
+			name: "corner, with diagonals",
+			got:  slices.Collect(g.Neighbours8(Point{0, 0})),
+			want: []Point{{1, 0}, {1, 1}, {0, 1}},
+		},
+		{
+			name: "centre, orthogonal",
+			got:  slices.Collect(g.Neighbours4(Point{1, 1})),
+			want: []Point{{1, 0}, {2, 1}, {1, 2}, {0, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("neighbours = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if got := len(slices.Collect(g.Neighbours8(Point{1, 1}))); got != 8 {
+		t.Errorf("Neighbours8(centre) returned %d points, want 8", got)
+	}
+}
+
+func TestDirections(t *testing.T) {
+	for _, d := range Directions {
+		if d.Clockwise().CounterClockwise() != d {
+			t.Errorf("%v: turning right then left did not return to the same direction", d)
+		}
+		if d.Clockwise().Clockwise() != d.Reverse() {
+			t.Errorf("%v: two right turns differ from Reverse()", d)
+		}
+		if d.Delta().RotateClockwise() != d.Clockwise().Delta() {
+			t.Errorf("%v: rotating the step clockwise differs from turning clockwise", d)
+		}
+		if d.Delta().RotateCounterClockwise() != d.CounterClockwise().Delta() {
+			t.Errorf("%v: rotating the step counter-clockwise differs from turning counter-clockwise", d)
+		}
+	}
+
+	if p := (Point{2, 2}).Move(North); p != (Point{2, 1}) {
+		t.Errorf("Move(North) = %v, want 2,1", p)
+	}
+
+	if d, ok := DirectionFromArrow('<'); !ok || d != West {
+		t.Errorf("DirectionFromArrow('<') = %v, %v, want West, true", d, ok)
+	}
+	if _, ok := DirectionFromArrow('x'); ok {
+		t.Error("DirectionFromArrow('x') accepted an invalid arrow")
+	}
+
+	for _, s := range []string{"south", "S", "v"} {
+		if d, err := ParseDirection(s); err != nil || d != South {
+			t.Errorf("ParseDirection(%q) = %v, %v, want South", s, d, err)
+		}
+	}
+	if _, err := ParseDirection("up"); err == nil {
+		t.Error("ParseDirection(\"up\") accepted an invalid direction")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	g, _ := FromLines([]string{"abc", "def"}, AsRune)
+	rotated := g.Rotate()
+
+	want, _ := FromLines([]string{"da", "eb", "fc"}, AsRune)
+	if !reflect.DeepEqual(rotated, want) {
+		t.Errorf("Rotate() = %v, want %v", rotated, want)
+	}
+}
+
+func TestManhattan(t *testing.T) {
+	if got := (Point{1, 3}).Manhattan(Point{5, 7}); got != 8 {
+		t.Errorf("Manhattan() = %d, want 8", got)
+	}
+	if got := (Point{5, -2}).Manhattan(Point{1, 3}); got != 9 {
+		t.Errorf("Manhattan() = %d, want 9", got)
+	}
+}
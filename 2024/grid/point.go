@@ -0,0 +1,88 @@
+// Package grid provides the point, direction and two-dimensional grid types
+// shared by the puzzles that take place on a map.
+//
+// Points use screen coordinates: X is the column and grows to the right, Y
+// is the row and grows downwards, so moving North decreases Y.
+package grid
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Point is a position on a grid, or an offset between two positions
+type Point struct {
+	X, Y int
+}
+
+// Offsets4 holds the steps to the four orthogonal neighbours, clockwise
+// from North.
+var Offsets4 = [4]Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// Offsets8 holds the steps to all eight neighbours, clockwise from North.
+var Offsets8 = [8]Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+
+// Add returns the point offset by q
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Sub returns the offset that leads from q to p
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Scale returns the point with both coordinates multiplied by k
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Move returns the neighbouring point one step in the given direction
+func (p Point) Move(d Direction) Point {
+	return p.Add(d.Delta())
+}
+
+// Manhattan returns the taxicab distance between two points
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// RotateClockwise turns the point a quarter turn clockwise about the origin,
+// so that an offset pointing North ends up pointing East.
+func (p Point) RotateClockwise() Point {
+	return Point{-p.Y, p.X}
+}
+
+// RotateCounterClockwise turns the point a quarter turn counter-clockwise
+// about the origin, so that an offset pointing North ends up pointing West.
+func (p Point) RotateCounterClockwise() Point {
+	return Point{p.Y, -p.X}
+}
+
+// Neighbours4 returns the four orthogonal neighbours, clockwise from North
+func (p Point) Neighbours4() [4]Point {
+	var neighbours [4]Point
+	for i, offset := range Offsets4 {
+		neighbours[i] = p.Add(offset)
+	}
+	return neighbours
+}
+
+// Neighbours8 returns all eight neighbours, clockwise from North
+func (p Point) Neighbours8() [8]Point {
+	var neighbours [8]Point
+	for i, offset := range Offsets8 {
+		neighbours[i] = p.Add(offset)
+	}
+	return neighbours
+}
+
+// String formats the point as "x,y", the way puzzles write coordinates
+func (p Point) String() string {
+	return fmt.Sprintf("%d,%d", p.X, p.Y)
+}
+
+func abs(x int) int {
+	mask := x >> (bits.UintSize - 1)
+	return (x ^ mask) - mask
+}
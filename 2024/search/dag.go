@@ -0,0 +1,106 @@
+package search
+
+import "container/heap"
+
+// DAG records every cheapest route from a start state to the cheapest goal
+// states. Each state keeps all of its optimal predecessors, so walking the
+// links back from the goals visits every state that lies on at least one
+// optimal path.
+type DAG[S comparable] struct {
+	start S
+	cost  int
+	goals []S
+	dist  map[S]int
+	prev  map[S][]S
+}
+
+// AllShortestPaths runs Dijkstra's algorithm from start, keeping every
+// predecessor that reaches a state at its optimal cost. The search stops once
+// the cheapest goal states have been found, and goal states are not expanded
+// further. It reports false if no goal state is reachable.
+func AllShortestPaths[S comparable](start S, edges Edges[S], goal func(S) bool) (*DAG[S], bool) {
+	dag := &DAG[S]{
+		start: start,
+		cost:  -1,
+		dist:  map[S]int{start: 0},
+		prev:  map[S][]S{},
+	}
+	queue := &priorityQueue[S]{}
+	heap.Push(queue, item[S]{state: start})
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(item[S])
+		if current.cost > dag.dist[current.state] {
+			continue
+		}
+		if dag.cost >= 0 && current.cost > dag.cost {
+			break // everything left is more expensive than the best goal
+		}
+
+		if goal(current.state) {
+			dag.cost = current.cost
+			dag.goals = append(dag.goals, current.state)
+			continue
+		}
+
+		for next, cost := range edges(current.state) {
+			nextCost := current.cost + cost
+			known, seen := dag.dist[next]
+			switch {
+			case !seen || nextCost < known:
+				dag.dist[next] = nextCost
+				dag.prev[next] = []S{current.state}
+				heap.Push(queue, item[S]{state: next, cost: nextCost, priority: nextCost})
+			case nextCost == known:
+				dag.prev[next] = append(dag.prev[next], current.state)
+			}
+		}
+	}
+
+	return dag, dag.cost >= 0
+}
+
+// Cost returns the cost of the optimal paths
+func (d *DAG[S]) Cost() int {
+	return d.cost
+}
+
+// Goals returns the goal states reached at the optimal cost
+func (d *DAG[S]) Goals() []S {
+	return d.goals
+}
+
+// Dist returns the cheapest known cost from the start to a state
+func (d *DAG[S]) Dist(s S) (int, bool) {
+	cost, ok := d.dist[s]
+	return cost, ok
+}
+
+// Predecessors returns the states from which s is reached at its optimal cost
+func (d *DAG[S]) Predecessors(s S) []S {
+	return d.prev[s]
+}
+
+// States returns every state that lies on at least one optimal path from
+// the start to a goal, including the start and the goals themselves.
+func (d *DAG[S]) States() map[S]bool {
+	onPath := make(map[S]bool)
+	stack := append([]S(nil), d.goals...)
+	for _, s := range stack {
+		onPath[s] = true
+	}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, p := range d.prev[current] {
+			if !onPath[p] {
+				onPath[p] = true
+				stack = append(stack, p)
+			}
+		}
+	}
+
+	return onPath
+}
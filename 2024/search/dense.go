@@ -0,0 +1,49 @@
+package search
+
+import "container/heap"
+
+// Unreachable is the distance Distances gives the states it never reaches
+const Unreachable = -1
+
+// Distances runs Dijkstra's algorithm from every source at once and returns
+// the cheapest cost of reaching each state, or Unreachable. The states are
+// numbered from 0 to size-1 by index, so the costs are kept in a slice
+// rather than a map, which is much faster for dense state spaces such as
+// the positions of a grid. States for which stop returns true are reached
+// but not left; stop may be nil.
+func Distances[S comparable](sources []S, edges Edges[S], index func(S) int, size int, stop func(S) bool) []int {
+	dist := make([]int, size)
+	for i := range dist {
+		dist[i] = Unreachable
+	}
+
+	// The queue holds the numbers of the states, which are smaller to move
+	// around than the states themselves
+	states := make([]S, size)
+	queue := &priorityQueue[int]{}
+	for _, s := range sources {
+		i := index(s)
+		dist[i], states[i] = 0, s
+		heap.Push(queue, item[int]{state: i})
+	}
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(item[int])
+		if current.cost > dist[current.state] {
+			continue // a cheaper route to this state was already expanded
+		}
+		if stop != nil && stop(states[current.state]) {
+			continue
+		}
+
+		for next, cost := range edges(states[current.state]) {
+			i, nextCost := index(next), current.cost+cost
+			if dist[i] == Unreachable || nextCost < dist[i] {
+				dist[i], states[i] = nextCost, next
+				heap.Push(queue, item[int]{state: i, cost: nextCost, priority: nextCost})
+			}
+		}
+	}
+
+	return dist
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"iter"
+	"slices"
+)
+
+// edge is a step between two states
+type edge[S comparable] struct {
+	from, to S
+}
+
+// KShortestPaths returns up to k loopless paths from start to a goal state,
+// cheapest first, using Yen's algorithm: each new path leaves one of the
+// paths already found at some state and takes the cheapest way to a goal
+// that does not repeat an earlier path or revisit the states before the
+// branch. Each branch is found with AStar and heuristic, which may be nil,
+// under the same conditions; a heuristic that gives the exact remaining
+// cost makes the search much faster.
+func KShortestPaths[S comparable](start S, edges Edges[S], goal func(S) bool, heuristic func(S) int, k int) []Path[S] {
+	if heuristic == nil {
+		heuristic = func(S) int { return 0 }
+	}
+	if k <= 0 {
+		return nil
+	}
+
+	first, ok := AStar(start, edges, goal, heuristic)
+	if !ok {
+		return nil
+	}
+
+	// Each path remembers where it branched off, as branching earlier than
+	// that would only find paths derived from its parent again
+	type branched struct {
+		Path[S]
+		from int
+	}
+
+	found := []Path[S]{first}
+	last := branched{Path: first}
+	var candidates []branched
+	for len(found) < k {
+		rootCost := 0
+		for i := 0; i < last.from; i++ {
+			rootCost += stepCost(edges, last.States[i], last.States[i+1])
+		}
+
+		for i := last.from; i < len(last.States)-1; i++ {
+			spur, root := last.States[i], last.States[:i+1]
+
+			// Block the next step of every path found so far that shares
+			// this root, and the root itself
+			blockedEdges := make(map[edge[S]]bool)
+			for _, p := range found {
+				if len(p.States) > i+1 && slices.Equal(p.States[:i+1], root) {
+					blockedEdges[edge[S]{p.States[i], p.States[i+1]}] = true
+				}
+			}
+			blockedStates := make(map[S]bool, i)
+			for _, s := range root[:i] {
+				blockedStates[s] = true
+			}
+			restricted := func(s S) iter.Seq2[S, int] {
+				return func(yield func(S, int) bool) {
+					for next, cost := range edges(s) {
+						if blockedStates[next] || blockedEdges[edge[S]{s, next}] {
+							continue
+						}
+						if !yield(next, cost) {
+							return
+						}
+					}
+				}
+			}
+
+			// Once there are enough candidates to finish, a branch only
+			// matters if it beats the last of those that would be used
+			limit := -1
+			if needed := k - len(found); len(candidates) >= needed {
+				costs := make([]int, len(candidates))
+				for j, c := range candidates {
+					costs[j] = c.Cost
+				}
+				slices.Sort(costs)
+				limit = costs[needed-1] - rootCost
+			}
+
+			if branch, ok := boundedAStar(spur, restricted, goal, heuristic, limit); ok {
+				candidate := Path[S]{
+					States: append(slices.Clone(root[:i]), branch.States...),
+					Cost:   rootCost + branch.Cost,
+				}
+				if !containsPath(found, candidate) && !slices.ContainsFunc(candidates, func(c branched) bool {
+					return slices.Equal(c.States, candidate.States)
+				}) {
+					candidates = append(candidates, branched{candidate, i})
+				}
+			}
+			rootCost += stepCost(edges, spur, last.States[i+1])
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		best := 0
+		for i, c := range candidates {
+			if c.Cost < candidates[best].Cost {
+				best = i
+			}
+		}
+		last = candidates[best]
+		candidates = slices.Delete(candidates, best, best+1)
+		found = append(found, last.Path)
+	}
+	return found
+}
+
+// stepCost returns the cheapest cost of stepping from one state to another
+func stepCost[S comparable](edges Edges[S], from, to S) int {
+	cheapest := -1
+	for next, cost := range edges(from) {
+		if next == to && (cheapest < 0 || cost < cheapest) {
+			cheapest = cost
+		}
+	}
+	return cheapest
+}
+
+// containsPath reports whether paths holds a path through the same states
+func containsPath[S comparable](paths []Path[S], path Path[S]) bool {
+	for _, p := range paths {
+		if slices.Equal(p.States, path.States) {
+			return true
+		}
+	}
+	return false
+}
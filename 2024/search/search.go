@@ -0,0 +1,149 @@
+// Package search provides generic graph searches over any comparable state
+// type. The graph is never built up front: callers describe it with a
+// function that yields the neighbours of a state, so the same searches work
+// for grid positions, positions with a heading, or anything else.
+package search
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// Neighbours yields the states reachable in a single unit-cost step
+type Neighbours[S comparable] func(S) iter.Seq[S]
+
+// Edges yields the states reachable in a single step together with the cost
+// of taking that step. Costs must not be negative.
+type Edges[S comparable] func(S) iter.Seq2[S, int]
+
+// Path is a route through the graph, from the start state to a goal state
+// inclusive, and its total cost
+type Path[S comparable] struct {
+	States []S
+	Cost   int
+}
+
+// BFS finds a path with the fewest steps from start to any state satisfying
+// goal. It reports false if no goal state is reachable.
+func BFS[S comparable](start S, neighbours Neighbours[S], goal func(S) bool) (Path[S], bool) {
+	queue := []S{start}
+	parent := map[S]S{}
+	visited := map[S]bool{start: true}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if goal(current) {
+			states := reconstruct(parent, start, current)
+			return Path[S]{States: states, Cost: len(states) - 1}, true
+		}
+
+		for next := range neighbours(current) {
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			parent[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	return Path[S]{}, false
+}
+
+// Dijkstra finds a cheapest path from start to any state satisfying goal.
+// It reports false if no goal state is reachable.
+func Dijkstra[S comparable](start S, edges Edges[S], goal func(S) bool) (Path[S], bool) {
+	return AStar(start, edges, goal, func(S) int { return 0 })
+}
+
+// AStar finds a cheapest path from start to any state satisfying goal,
+// using heuristic to explore promising states first. The heuristic must
+// never overestimate the remaining cost and must be consistent, or the path
+// found may not be the cheapest.
+func AStar[S comparable](start S, edges Edges[S], goal func(S) bool, heuristic func(S) int) (Path[S], bool) {
+	return boundedAStar(start, edges, goal, heuristic, -1)
+}
+
+// boundedAStar is AStar giving up on paths that cost more than limit, unless
+// limit is negative
+func boundedAStar[S comparable](start S, edges Edges[S], goal func(S) bool, heuristic func(S) int, limit int) (Path[S], bool) {
+	dist := map[S]int{start: 0}
+	parent := map[S]S{}
+	queue := &priorityQueue[S]{}
+	heap.Push(queue, item[S]{state: start, cost: 0, priority: heuristic(start)})
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(item[S])
+		if current.cost > dist[current.state] {
+			continue // a cheaper route to this state was already expanded
+		}
+		if limit >= 0 && current.priority > limit {
+			break // every path left costs more than the limit
+		}
+
+		if goal(current.state) {
+			return Path[S]{States: reconstruct(parent, start, current.state), Cost: current.cost}, true
+		}
+
+		for next, cost := range edges(current.state) {
+			nextCost := current.cost + cost
+			if known, seen := dist[next]; seen && known <= nextCost {
+				continue
+			}
+			dist[next] = nextCost
+			parent[next] = current.state
+			heap.Push(queue, item[S]{state: next, cost: nextCost, priority: nextCost + heuristic(next)})
+		}
+	}
+
+	return Path[S]{}, false
+}
+
+// reconstruct walks the parent links back from the goal to the start and
+// returns the states in the order they are visited
+func reconstruct[S comparable](parent map[S]S, start, goal S) []S {
+	path := []S{goal}
+	for current := goal; current != start; {
+		current = parent[current]
+		path = append(path, current)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// item is an entry in the priority queue
+type item[S comparable] struct {
+	state    S
+	cost     int // cost of the route from the start
+	priority int // cost plus the heuristic estimate of the remaining cost
+}
+
+// priorityQueue is a min-heap of items ordered by priority. Among items of
+// equal priority the one furthest from the start comes first, which lets
+// AStar with an exact heuristic head straight for the goal.
+type priorityQueue[S comparable] []item[S]
+
+func (pq priorityQueue[S]) Len() int      { return len(pq) }
+func (pq priorityQueue[S]) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i] }
+
+func (pq priorityQueue[S]) Less(i, j int) bool {
+	if pq[i].priority != pq[j].priority {
+		return pq[i].priority < pq[j].priority
+	}
+	return pq[i].cost > pq[j].cost
+}
+
+func (pq *priorityQueue[S]) Push(x any) {
+	*pq = append(*pq, x.(item[S]))
+}
+
+func (pq *priorityQueue[S]) Pop() any {
+	old := *pq
+	n := len(old)
+	it := old[n-1]
+	*pq = old[:n-1]
+	return it
+}
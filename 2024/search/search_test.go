@@ -0,0 +1,225 @@
+package search
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// graph is a small weighted directed graph used by the tests:
+//
+//	1 --1--> 2 --1--> 4
+//	|                 ^
+//	+--3--> 3 ---0----+
+//	        |
+//	        +--5--> 5
+var graph = map[int]map[int]int{
+	1: {2: 1, 3: 3},
+	2: {4: 1},
+	3: {4: 0, 5: 5},
+}
+
+func weighted(s int) iter.Seq2[int, int] {
+	return maps.All(graph[s])
+}
+
+func unweighted(s int) iter.Seq[int] {
+	return maps.Keys(graph[s])
+}
+
+func is(target int) func(int) bool {
+	return func(s int) bool { return s == target }
+}
+
+func TestBFS(t *testing.T) {
+	tests := []struct {
+		name   string
+		goal   int
+		want   []int
+		wantOK bool
+	}{
+		{name: "start is goal", goal: 1, want: []int{1}, wantOK: true},
+		{name: "fewest steps", goal: 5, want: []int{1, 3, 5}, wantOK: true},
+		{name: "unreachable", goal: 6, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := BFS(1, unweighted, is(tt.goal))
+			if ok != tt.wantOK {
+				t.Fatalf("BFS() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(path.States, tt.want) {
+				t.Errorf("BFS() states = %v, want %v", path.States, tt.want)
+			}
+			if ok && path.Cost != len(tt.want)-1 {
+				t.Errorf("BFS() cost = %d, want %d", path.Cost, len(tt.want)-1)
+			}
+		})
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	tests := []struct {
+		goal     int
+		want     []int
+		wantCost int
+	}{
+		{goal: 4, want: []int{1, 2, 4}, wantCost: 2},
+		{goal: 5, want: []int{1, 3, 5}, wantCost: 8},
+	}
+
+	for _, tt := range tests {
+		path, ok := Dijkstra(1, weighted, is(tt.goal))
+		if !ok {
+			t.Fatalf("Dijkstra() to %d found no path", tt.goal)
+		}
+		if path.Cost != tt.wantCost || !reflect.DeepEqual(path.States, tt.want) {
+			t.Errorf("Dijkstra() to %d = %v (cost %d), want %v (cost %d)",
+				tt.goal, path.States, path.Cost, tt.want, tt.wantCost)
+		}
+	}
+
+	if _, ok := Dijkstra(4, weighted, is(1)); ok {
+		t.Error("Dijkstra() found a path against the direction of the edges")
+	}
+}
+
+func TestAStar(t *testing.T) {
+	// A corridor along the number line where each step costs one, so the
+	// distance to the target is an exact heuristic.
+	const target = 40
+	line := func(s int) iter.Seq2[int, int] {
+		return func(yield func(int, int) bool) {
+			_ = yield(s-1, 1) && yield(s+1, 1)
+		}
+	}
+
+	expanded := 0
+	counting := func(s int) iter.Seq2[int, int] {
+		expanded++
+		return line(s)
+	}
+	heuristic := func(s int) int { return max(target-s, s-target) }
+
+	path, ok := AStar(0, counting, is(target), heuristic)
+	if !ok || path.Cost != target {
+		t.Fatalf("AStar() = cost %d, ok %v, want cost %d", path.Cost, ok, target)
+	}
+	if expanded != target {
+		t.Errorf("AStar() expanded %d states, want %d with an exact heuristic", expanded, target)
+	}
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	dag, ok := AllShortestPaths(1, weighted, is(4))
+	if !ok {
+		t.Fatal("AllShortestPaths() found no path")
+	}
+	if dag.Cost() != 2 {
+		t.Errorf("Cost() = %d, want 2", dag.Cost())
+	}
+
+	// Make 1-2-4 and 1-3-4 cost the same and check that both routes are kept
+	tiedGraph := map[int]map[int]int{
+		1: {2: 1, 3: 1},
+		2: {4: 1},
+		3: {4: 1, 5: 5},
+	}
+	tied := func(s int) iter.Seq2[int, int] {
+		return maps.All(tiedGraph[s])
+	}
+	dag, ok = AllShortestPaths(1, tied, is(4))
+	if !ok {
+		t.Fatal("AllShortestPaths() with tied routes found no path")
+	}
+
+	predecessors := slices.Sorted(slices.Values(dag.Predecessors(4)))
+	if !reflect.DeepEqual(predecessors, []int{2, 3}) {
+		t.Errorf("Predecessors(4) = %v, want [2 3]", predecessors)
+	}
+
+	states := slices.Sorted(maps.Keys(dag.States()))
+	if !reflect.DeepEqual(states, []int{1, 2, 3, 4}) {
+		t.Errorf("States() = %v, want [1 2 3 4]", states)
+	}
+	if dist, _ := dag.Dist(3); dist != 1 {
+		t.Errorf("Dist(3) = %d, want 1", dist)
+	}
+
+	if _, ok := AllShortestPaths(4, weighted, is(1)); ok {
+		t.Error("AllShortestPaths() found a path against the direction of the edges")
+	}
+}
+
+func TestDistances(t *testing.T) {
+	index := func(s int) int { return s }
+
+	tests := []struct {
+		name    string
+		sources []int
+		stop    func(int) bool
+		want    []int
+	}{
+		{"from one state", []int{1}, nil, []int{Unreachable, 0, 1, 3, 2, 8}},
+		{"from several states", []int{1, 3}, nil, []int{Unreachable, 0, 1, 0, 0, 5}},
+		{"stopping at a state", []int{1}, is(3), []int{Unreachable, 0, 1, 3, 2, Unreachable}},
+		{"from a dead end", []int{5}, nil, []int{Unreachable, Unreachable, Unreachable, Unreachable, Unreachable, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distances(tt.sources, weighted, index, 6, tt.stop); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Distances() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKShortestPaths(t *testing.T) {
+	// Four loopless routes from 1 to 4, and a loop back to 1 that must
+	// not be taken
+	routes := map[int]map[int]int{
+		1: {2: 1, 3: 2, 4: 5},
+		2: {3: 0, 4: 2},
+		3: {4: 1, 1: 0},
+	}
+	edges := func(s int) iter.Seq2[int, int] {
+		return maps.All(routes[s])
+	}
+
+	tests := []struct {
+		k         int
+		wantCosts []int
+	}{
+		{k: 0},
+		{k: 1, wantCosts: []int{2}},
+		{k: 3, wantCosts: []int{2, 3, 3}},
+		{k: 10, wantCosts: []int{2, 3, 3, 5}},
+	}
+
+	for _, tt := range tests {
+		var costs []int
+		var paths [][]int
+		for _, path := range KShortestPaths(1, edges, is(4), nil, tt.k) {
+			costs = append(costs, path.Cost)
+			paths = append(paths, path.States)
+		}
+		if !reflect.DeepEqual(costs, tt.wantCosts) {
+			t.Errorf("KShortestPaths(k=%d) costs = %v, want %v", tt.k, costs, tt.wantCosts)
+		}
+		if tt.k == 10 {
+			want := [][]int{{1, 2, 3, 4}, {1, 2, 4}, {1, 3, 4}, {1, 4}}
+			slices.SortFunc(paths, slices.Compare)
+			if !reflect.DeepEqual(paths, want) {
+				t.Errorf("KShortestPaths() paths = %v, want %v", paths, want)
+			}
+		}
+	}
+
+	if paths := KShortestPaths(4, edges, is(1), nil, 3); len(paths) != 0 {
+		t.Errorf("KShortestPaths() found %v against the direction of the edges", paths)
+	}
+}
@@ -0,0 +1,98 @@
+// Package templates holds the skeleton of a new day, which "aoc new" copies
+// into place in the same way as 2025/scripts/new-day.sh does for Rust.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed day-template
+var dayTemplate embed.FS
+
+// placeholderExample stands in for the puzzle's example until it is pasted
+// into the generated test.
+const placeholderExample = "TODO: Add example input from puzzle\n"
+
+// Day describes the day being generated
+type Day struct {
+	Year, Day int
+	Example   string // Go literal of the example input
+}
+
+// outputPath maps a template file to where it is written in the day's
+// directory.
+func outputPath(name string, day int) string {
+	name = strings.TrimSuffix(name, ".tmpl")
+	if name == "main.go" {
+		return filepath.Join("cmd", fmt.Sprintf("day%02d", day), name)
+	}
+	return name
+}
+
+// DayDir returns the directory of a day relative to its year directory
+func DayDir(day int) string {
+	return fmt.Sprintf("day-%02d", day)
+}
+
+// WriteDay creates the directory of a new day below yearDir from the
+// template and returns the files it wrote. An empty example leaves a
+// placeholder in the test. It refuses to touch a day that already exists.
+func WriteDay(yearDir string, year, day int, example string) ([]string, error) {
+	dir := filepath.Join(yearDir, DayDir(day))
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if example == "" {
+		example = placeholderExample
+	}
+	data := Day{Year: year, Day: day, Example: goLiteral(example)}
+
+	entries, err := fs.ReadDir(dayTemplate, "day-template")
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, entry := range entries {
+		tmpl, err := template.ParseFS(dayTemplate, "day-template/"+entry.Name())
+		if err != nil {
+			return written, fmt.Errorf("parsing template %s: %w", entry.Name(), err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return written, fmt.Errorf("executing template %s: %w", entry.Name(), err)
+		}
+
+		path := filepath.Join(dir, outputPath(entry.Name(), day))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// goLiteral quotes text as a Go string, preferring a raw string so that an
+// example grid stays readable in the test.
+func goLiteral(s string) string {
+	if strings.ContainsAny(s, "`\r") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
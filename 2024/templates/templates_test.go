@@ -0,0 +1,73 @@
+package templates
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteDay(t *testing.T) {
+	yearDir := t.TempDir()
+
+	written, err := WriteDay(yearDir, 2024, 7, "190: 10 19\n")
+	if err != nil {
+		t.Fatalf("WriteDay() error = %v", err)
+	}
+
+	want := []string{
+		"README.md",
+		"bench_test.go",
+		"input.txt",
+		filepath.Join("cmd", "day07", "main.go"),
+		"solution.go",
+		"solution_test.go",
+	}
+	if len(written) != len(want) {
+		t.Fatalf("WriteDay() wrote %v, want %v", written, want)
+	}
+	for i, name := range want {
+		if got := written[i]; got != filepath.Join(yearDir, "day-07", name) {
+			t.Errorf("WriteDay() file %d = %s, want %s", i, got, name)
+		}
+	}
+
+	for _, path := range written {
+		if !strings.HasSuffix(path, ".go") {
+			continue
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), path, nil, 0); err != nil {
+			t.Errorf("generated %s does not parse: %v", path, err)
+		}
+	}
+
+	test, err := os.ReadFile(filepath.Join(yearDir, "day-07", "solution_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(test), "const example = `190: 10 19\n`") {
+		t.Errorf("generated test does not hold the example:\n%s", test)
+	}
+
+	if _, err := WriteDay(yearDir, 2024, 7, ""); err == nil {
+		t.Error("WriteDay() overwrote an existing day")
+	}
+}
+
+func TestGoLiteral(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"#.#\n", "`#.#\n`"},
+		{"a`b", `"a` + "`" + `b"`},
+		{"a\r\nb", `"a\r\nb"`},
+	}
+
+	for _, tt := range tests {
+		if got := goLiteral(tt.in); got != tt.want {
+			t.Errorf("goLiteral(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}